@@ -0,0 +1,298 @@
+// Command doyoucompute-templates generates a project's community files.
+//
+// Install it with:
+//
+//	go install github.com/MoonMoon1919/doyoucompute-templates/cmd/doyoucompute-templates@latest
+//
+// Then run init from the root of a repository:
+//
+//	doyoucompute-templates init --dry-run
+//	doyoucompute-templates init --only readme --only contributing --force
+//
+// Or declare the documents in a .doyoucompute.yaml file, written by hand or by
+// answering the wizard's questions, and generate them with:
+//
+//	doyoucompute-templates wizard
+//	doyoucompute-templates generate --dry-run
+//
+// Variants of the templates declared under derived in the config file are generated
+// alongside them. List the templates with their default paths with:
+//
+//	doyoucompute-templates templates
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/scaffold"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/templates"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/wizard"
+	"github.com/urfave/cli/v3"
+)
+
+// interactive reports whether stdin is a terminal that can answer prompts.
+func interactive() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+func initCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "init",
+		Usage: "Write the README, contributing guide, pull request and bug report templates for a project",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Value: ".",
+				Usage: "The root of the repository to write the files to",
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "The project name, detected from the repository when omitted",
+			},
+			&cli.StringFlag{
+				Name:  "repository",
+				Usage: "The repository url, detected from the origin remote when omitted",
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "A short description used to introduce the README",
+			},
+			&cli.StringSliceFlag{
+				Name:  "only",
+				Usage: fmt.Sprintf("Only write the named templates, one of %v", scaffold.TEMPLATES),
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would be written without writing anything",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite existing files that differ from the generated ones",
+			},
+			&cli.BoolFlag{
+				Name:  "no-input",
+				Usage: "Never prompt for metadata that could not be detected",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			dir := c.String("dir")
+
+			project, err := scaffold.DetectProject(os.DirFS(dir))
+			if err != nil {
+				return err
+			}
+
+			if name := c.String("name"); name != "" {
+				project.Name = name
+			}
+
+			if repository := c.String("repository"); repository != "" {
+				if err := project.SetRepository(repository); err != nil {
+					return err
+				}
+			}
+
+			project.Description = c.String("description")
+
+			if !c.Bool("no-input") && interactive() {
+				project, err = scaffold.Ask(os.Stdin, os.Stdout, project)
+				if err != nil {
+					return err
+				}
+			}
+
+			var opts []doyoucompute.OptionBuilder[scaffold.InitProps]
+
+			if only := c.StringSlice("only"); len(only) > 0 {
+				opts = append(opts, scaffold.WithOnly(only...))
+			}
+			if c.Bool("dry-run") {
+				opts = append(opts, scaffold.WithDryRun())
+			}
+			if c.Bool("force") {
+				opts = append(opts, scaffold.WithForce())
+			}
+
+			return scaffold.Init(dir, project, os.Stdout, opts...)
+		},
+	}
+}
+
+func generateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Write the documents declared in a config file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Value: ".",
+				Usage: "The root of the repository to write the files to",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Value: config.DEFAULT_PATH,
+				Usage: "The config file, relative to the repository root",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would be written without writing anything",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite existing files that differ from the generated ones",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			dir := c.String("dir")
+
+			cfg, err := config.Load(os.DirFS(dir), c.String("config"))
+			if err != nil {
+				return err
+			}
+
+			ts, err := templates.Configured(cfg)
+			if err != nil {
+				return err
+			}
+
+			docs, err := templates.Documents(cfg, ts...)
+			if err != nil {
+				return err
+			}
+
+			var opts []doyoucompute.OptionBuilder[scaffold.InitProps]
+
+			if c.Bool("dry-run") {
+				opts = append(opts, scaffold.WithDryRun())
+			}
+			if c.Bool("force") {
+				opts = append(opts, scaffold.WithForce())
+			}
+
+			return scaffold.Apply(dir, docs, os.Stdout, opts...)
+		},
+	}
+}
+
+func wizardCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "wizard",
+		Usage: "Answer questions about the project to write a config file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Value: ".",
+				Usage: "The root of the repository to detect metadata from and write the config file to",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Value: config.DEFAULT_PATH,
+				Usage: "The config file to write, relative to the repository root",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite an existing config file",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			dir := c.String("dir")
+			name := filepath.Join(dir, filepath.FromSlash(c.String("config")))
+
+			if _, err := os.Stat(name); err == nil && !c.Bool("force") {
+				return fmt.Errorf("%s already exists, rerun with force to replace it", name)
+			}
+
+			info, err := metadata.Detect(os.DirFS(dir))
+			if err != nil {
+				return err
+			}
+
+			cfg, err := wizard.Run(os.Stdin, os.Stdout, info)
+			if err != nil {
+				return err
+			}
+
+			content, err := cfg.Encode()
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(name, content, 0o644); err != nil {
+				return fmt.Errorf("could not write %s: %w", name, err)
+			}
+
+			fmt.Printf("Wrote %s, run generate to write the documents\n", name)
+
+			return nil
+		},
+	}
+}
+
+func templatesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "templates",
+		Usage: "List the templates that can be generated, including those derived in the config file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "dir",
+				Value: ".",
+				Usage: "The root of the repository to read the config file from",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Value: config.DEFAULT_PATH,
+				Usage: "The config file, relative to the repository root",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			ts := templates.Builtin()
+
+			// Without a config file only the built-in templates are listed
+			cfg, err := config.Load(os.DirFS(c.String("dir")), c.String("config"))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			if err == nil {
+				if ts, err = templates.Configured(cfg); err != nil {
+					return err
+				}
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+			fmt.Fprintln(w, "NAME\tPATH\tDESCRIPTION")
+			for _, t := range ts {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name(), t.DefaultPath(), t.Description())
+			}
+
+			return w.Flush()
+		},
+	}
+}
+
+func main() {
+	cmd := &cli.Command{
+		Name:     "doyoucompute-templates",
+		Usage:    "Generate community files with doyoucompute",
+		Commands: []*cli.Command{initCommand(), generateCommand(), wizardCommand(), templatesCommand()},
+	}
+
+	if err := cmd.Run(context.Background(), os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
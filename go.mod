@@ -2,9 +2,8 @@ module github.com/MoonMoon1919/doyoucompute-templates
 
 go 1.23.7
 
-require github.com/MoonMoon1919/doyoucompute v0.1.2
-
 require (
-	github.com/urfave/cli/v3 v3.3.8 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	github.com/MoonMoon1919/doyoucompute v0.1.2
+	github.com/urfave/cli/v3 v3.3.8
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -1,8 +1,12 @@
 package docs
 
 import (
+	"io/fs"
+
 	"github.com/MoonMoon1919/doyoucompute"
 	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
 )
 
 func writingDocs() doyoucompute.Section {
@@ -27,20 +31,16 @@ func writingDocs() doyoucompute.Section {
 	return docsSection
 }
 
-func license() doyoucompute.Section {
-	licenseSection := doyoucompute.NewSection("License")
-	licenseSection.WriteParagraph().
-		Text("By contributing, you agree that your contributions will be licensed under the project's").
-		Link("MIT License.", "./LICENSE")
-
-	return licenseSection
-}
+func Contributing(fsys fs.FS, info metadata.ProjectInfo) (doyoucompute.Document, error) {
+	targets, err := makefile.ParseFile(fsys, "Makefile")
+	if err != nil {
+		return doyoucompute.Document{}, err
+	}
 
-func Contributing() (doyoucompute.Document, error) {
-	return contributing.New(
-		"https://github.com/MoonMoon1919/doyoucompute-templates",
-		"https://github.com/MoonMoon1919/doyoucompute-templates/issues",
+	return contributing.NewFromProject(
+		info,
+		contributing.WithMakefileTasks(makefile.Documented(targets)),
 		contributing.WithWritingDocs(writingDocs()),
-		contributing.WithLicense(license()),
+		contributing.WithTableOfContents(3),
 	)
 }
@@ -1,10 +1,13 @@
 package docs
 
 import (
-	"os"
+	"io/fs"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/internal/samples"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
 	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/snippet"
 )
 
 func features() (doyoucompute.Section, error) {
@@ -28,7 +31,7 @@ func features() (doyoucompute.Section, error) {
 }
 
 func basicUsage() (doyoucompute.Section, error) {
-	sample, err := os.ReadFile("./internal/samples/basics.go")
+	sample, err := snippet.LoadRegion(samples.Sources, "basics.go", "basics")
 	if err != nil {
 		return doyoucompute.Section{}, err
 	}
@@ -40,23 +43,71 @@ func basicUsage() (doyoucompute.Section, error) {
 			Code("New").
 			Text("method for the associated document.")
 
-		s.WriteCodeBlock("go", []string{string(sample)}, doyoucompute.Static)
+		s.WriteCodeBlock("go", []string{sample.Code}, doyoucompute.Static)
 
 		return nil
 	})
 }
 
-func quickstart() (doyoucompute.Section, error) {
+func customOptions() (doyoucompute.Section, error) {
+	sample, err := snippet.LoadRegion(samples.Sources, "options.go", "options")
+	if err != nil {
+		return doyoucompute.Section{}, err
+	}
+
+	return doyoucompute.SectionFactory("Writing your own options", func(s *doyoucompute.Section) error {
+		s.WriteIntro().
+			Text("Every document exports its props type and an").
+			Code("Option").
+			Text("alias, so options can be written and bundled into presets outside this module.").
+			Text("Options run in the order given, each followed by the finalizer it returns.").
+			Text("Props fields are only ever added within a major version, never renamed or removed, so custom options keep compiling across minor releases.")
+
+		s.WriteCodeBlock("go", []string{sample.Code}, doyoucompute.Static)
+
+		return nil
+	})
+}
+
+func quickstart(info metadata.ProjectInfo) (doyoucompute.Section, error) {
 	basics, err := basicUsage()
 	if err != nil {
 		return doyoucompute.Section{}, err
 	}
 
+	options, err := customOptions()
+	if err != nil {
+		return doyoucompute.Section{}, err
+	}
+
 	return doyoucompute.SectionFactory("Quickstart", func(s *doyoucompute.Section) error {
 		installation := s.CreateSection("Installation")
-		installation.WriteCodeBlock("bash", []string{"go get github.com/MoonMoon1919/doyoucompute-templates"}, doyoucompute.Static)
+		installation.WriteCodeBlock("bash", []string{info.InstallCommand()}, doyoucompute.Static)
 
 		installation.AddSection(basics)
+		installation.AddSection(options)
+
+		scaffolding := s.CreateSection("Scaffolding a project")
+		scaffolding.WriteIntro().
+			Text("The").
+			Code("doyoucompute-templates").
+			Text("command generates the README, contributing guide, pull request and bug report templates for an existing repository.").
+			Text("Existing files are only overwritten with").
+			Code("--force").
+			Text("and otherwise just have their differences shown.")
+		scaffolding.WriteCodeBlock("bash", []string{"go run github.com/MoonMoon1919/doyoucompute-templates/cmd/doyoucompute-templates@latest init --dry-run"}, doyoucompute.Static)
+
+		scaffolding.WriteParagraph().
+			Text("To configure the templates without writing Go, declare them in a").
+			Code(".doyoucompute.yaml").
+			Text("file as described in").
+			Link("the config package", "./pkg/config/config.go").
+			Text("and run the").
+			Code("generate").
+			Text("command instead.").
+			Text("The").
+			Code("wizard").
+			Text("command writes a first version of that file by asking about the project and previewing the result.")
 
 		return nil
 	})
@@ -67,6 +118,15 @@ func availableDocs() (doyoucompute.Section, error) {
 		s.WriteIntro().
 			Text("This package contains several different documents, each with configurable options")
 
+		s.WriteParagraph().
+			Text("Every document can also be extended and shared:")
+
+		extensions := s.CreateList(doyoucompute.BULLET)
+		extensions.Append("Custom options: every document takes options written outside this module, as described in [Writing your own options](#writing-your-own-options).")
+		extensions.Append("Templates: [the templates package](./pkg/templates/templates.go) offers each document as a `Template` with a name, default path and description, built from a config file, so tools can handle the built-in documents and their own in the same way. Run `doyoucompute-templates templates` to list them.")
+		extensions.Append("Derived templates: `templates.Derive` derives an organisation's variant of a document, with its own path, frontmatter and sections, and each team's variant of that. Variants declared under `derived` in the config file are picked up by the `generate` command.")
+		extensions.Append("Organisation profiles: an `org.Profile` published in a Go module shares the security contact, license, issue tracker host, labels and assignees across repositories. Its `Apply` method fills in the values a repository's config leaves out and reports them. Repositories that build their documents in Go pass their own options through the profile's `ReadmeOptions` and its contributing guide and bug report counterparts, and those options win over the profile's.")
+
 		s.WriteParagraph().
 			Text("For additional example usage").
 			Text("See the docs in").
@@ -114,8 +174,8 @@ func disclaimers() (doyoucompute.Section, error) {
 	})
 }
 
-func ReadMe() (doyoucompute.Document, error) {
-	quickstartSection, err := quickstart()
+func ReadMe(fsys fs.FS, info metadata.ProjectInfo) (doyoucompute.Document, error) {
+	quickstartSection, err := quickstart(info)
 	if err != nil {
 		return doyoucompute.Document{}, err
 	}
@@ -148,5 +208,7 @@ func ReadMe() (doyoucompute.Document, error) {
 			availableDocsSection,
 			disclaimerSection,
 		},
+		readme.WithProjectInfo(info),
+		readme.WithProjectBadges(info, fsys),
 	)
 }
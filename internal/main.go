@@ -1,18 +1,154 @@
 package main
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 
+	"github.com/MoonMoon1919/doyoucompute"
 	"github.com/MoonMoon1919/doyoucompute-templates/internal/docs"
 	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/doccheck"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
 	"github.com/MoonMoon1919/doyoucompute-templates/pkg/pullrequest"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/registry"
 	"github.com/MoonMoon1919/doyoucompute/pkg/app"
 )
 
+// report prints each finding and returns an error if any of them are errors.
+func report(findings []doccheck.Finding) error {
+	failed := 0
+	for _, finding := range findings {
+		fmt.Fprintln(os.Stderr, finding)
+
+		if finding.Severity == doccheck.Error {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("found %d errors in the generated documents", failed)
+	}
+
+	return nil
+}
+
+// checkCode type-checks the Go code blocks of each registered document.
+func checkCode(_ fs.FS, docs *registry.Registry) error {
+	checker, err := doccheck.NewChecker(".")
+	if err != nil {
+		return err
+	}
+
+	var findings []doccheck.Finding
+	for _, entry := range docs.Entries() {
+		found, err := checker.Check(entry.Path, entry.Document)
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, found...)
+	}
+
+	return report(findings)
+}
+
+// checkLinks resolves the links of each registered document against the repository.
+func checkLinks(root fs.FS, docs *registry.Registry) error {
+	findings, err := doccheck.CheckLinks(root, docs.Entries()...)
+	if err != nil {
+		return err
+	}
+
+	return report(findings)
+}
+
+// renderAll renders each registered document to its path.
+func renderAll(_ fs.FS, docs *registry.Registry) error {
+	svc, err := doyoucompute.DefaultService()
+	if err != nil {
+		return err
+	}
+
+	if err := docs.RenderAll(svc); err != nil {
+		return err
+	}
+
+	for _, entry := range docs.Entries() {
+		fmt.Printf("✅ Rendered '%s' to '%s'\n", entry.Document.Name, entry.Path)
+	}
+
+	return nil
+}
+
+// compareAll checks each registered document matches the file at its path.
+func compareAll(_ fs.FS, docs *registry.Registry) error {
+	svc, err := doyoucompute.DefaultService()
+	if err != nil {
+		return err
+	}
+
+	comparisons, err := docs.CompareAll(svc)
+	if err != nil {
+		return err
+	}
+
+	for _, comparison := range comparisons {
+		switch {
+		case comparison.Missing:
+			fmt.Printf("❌ '%s' has not been rendered\n", comparison.Entry.Path)
+		case !comparison.Matches:
+			fmt.Printf("❌ '%s' does not match '%s'\n", comparison.Entry.Path, comparison.Entry.Document.Name)
+		default:
+			fmt.Printf("✅ '%s' is up to date\n", comparison.Entry.Path)
+		}
+	}
+
+	if stale := registry.Stale(comparisons); len(stale) > 0 {
+		return fmt.Errorf("%d documents are out of date, run 'make docs' to update them", len(stale))
+	}
+
+	return nil
+}
+
+// writeSchema writes the JSON Schema for config files to its checked-in path.
+func writeSchema(_ fs.FS, _ *registry.Registry) error {
+	schema, err := config.Schema()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(config.SCHEMA_PATH, schema, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Wrote the config schema to '%s'\n", config.SCHEMA_PATH)
+
+	return nil
+}
+
+// commands run against every registered document, in addition to the app's per-document commands.
+var commands = map[string]func(root fs.FS, docs *registry.Registry) error{
+	"render-all":  renderAll,
+	"compare-all": compareAll,
+	"check-code":  checkCode,
+	"check-links": checkLinks,
+	"schema":      writeSchema,
+}
+
 func main() {
 	app := app.Default()
 
-	bugreport, err := bugreport.New()
+	root := os.DirFS(".")
+
+	info, err := metadata.Detect(root)
+	if err != nil {
+		panic(err)
+	}
+
+	bugreport, err := bugreport.New(bugreport.WithLabels(labels.Bug()))
 	if err != nil {
 		panic(err)
 	}
@@ -22,20 +158,38 @@ func main() {
 		panic(err)
 	}
 
-	contributing, err := docs.Contributing()
+	contributing, err := docs.Contributing(root, info)
 	if err != nil {
 		panic(err)
 	}
 
-	readme, err := docs.ReadMe()
+	readme, err := docs.ReadMe(root, info)
 	if err != nil {
 		panic(err)
 	}
 
-	app.Register(readme)
-	app.Register(bugreport)
-	app.Register(pullrequest)
-	app.Register(contributing)
+	documents, err := registry.New(
+		registry.ReadMe(readme),
+		registry.Contributing(contributing),
+		registry.PullRequest(pullrequest),
+		registry.BugReport(bugreport),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(root, documents); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	documents.Register(app)
 
 	app.Run(os.Args)
 }
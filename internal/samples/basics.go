@@ -8,6 +8,7 @@ import (
 )
 
 func Basics() {
+	// snippet:start basics
 	// With defaults
 	br, err := bugreport.New()
 	if err != nil {
@@ -45,6 +46,7 @@ func Basics() {
 	if err != nil {
 		panic(err)
 	}
+	// snippet:end basics
 
 	fmt.Print(br)
 	fmt.Print(bugreportOptions)
@@ -0,0 +1,41 @@
+package samples
+
+import (
+	"fmt"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+)
+
+// snippet:start options
+// WithTriage is a preset combining built-in options with a custom one
+func WithTriage(team string) []bugreport.Option {
+	return []bugreport.Option{
+		bugreport.WithLabels(labels.Bug(), labels.Label{Name: "needs triage"}),
+		withOwner(team),
+	}
+}
+
+func withOwner(team string) bugreport.Option {
+	return func(p *bugreport.BugReportProps) (doyoucompute.Finalizer[bugreport.BugReportProps], error) {
+		if team == "" {
+			return nil, fmt.Errorf("team cannot be empty")
+		}
+
+		p.EnvironmentDetails.WriteParagraph().Text("Reports are triaged by " + team + ".")
+
+		return nil, nil
+	}
+}
+
+// snippet:end options
+
+func Options() {
+	report, err := bugreport.New(WithTriage("@acme/platform")...)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(report)
+}
@@ -0,0 +1,8 @@
+package samples
+
+import "embed"
+
+// Sources holds the sample files so documents can quote them from any working directory.
+//
+//go:embed basics.go options.go
+var Sources embed.FS
@@ -17,25 +17,74 @@
 //		bugreport.WithExpectedBehavior(customSection),
 //		bugreport.WithActualBehavior(customSection),
 //	)
+//
+// Writing your own option, which runs alongside the built-in ones in the order given:
+//
+//	func WithSecurityNotice(email string) bugreport.Option {
+//		return func(p *bugreport.BugReportProps) (doyoucompute.Finalizer[bugreport.BugReportProps], error) {
+//			p.ExpectedBehavior.WriteParagraph().Text("Report vulnerabilities privately to " + email + ".")
+//			return nil, nil
+//		}
+//	}
 package bugreport
 
 import (
 	"fmt"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
 const DEFAULT_NAME = "Bug Report"
 
-type bugReportProps struct {
-	name               string
-	frontmatter        doyoucompute.Frontmatter
-	expectedBehavior   doyoucompute.Section
-	actualBehavior     doyoucompute.Section
-	environmentDetails doyoucompute.Section
-	reproductionSteps  doyoucompute.Section
-	codeSamples        doyoucompute.Section
-	errors             doyoucompute.Section
+// DEFAULT_PATH is where GitHub looks for the bug report issue template.
+const DEFAULT_PATH = ".github/ISSUE_TEMPLATE/bug_report.md"
+
+// BugReportProps holds everything New renders. Defaults returns the values New starts from,
+// and each option then changes them in the order given, so options outside this package
+// can be written as any doyoucompute.OptionBuilder[BugReportProps].
+//
+// See the package documentation for writing options.
+type BugReportProps struct {
+	// Document name, also used as the template name in the frontmatter
+	Name string
+	// Frontmatter GitHub reads to list the template
+	Frontmatter doyoucompute.Frontmatter
+	// Labels applied to new bug reports, kept in step with the frontmatter by WithLabels
+	Labels []labels.Label
+	// Expected behavior section
+	ExpectedBehavior doyoucompute.Section
+	// Actual behavior section
+	ActualBehavior doyoucompute.Section
+	// Environment details section
+	EnvironmentDetails doyoucompute.Section
+	// Steps to reproduce section
+	ReproductionSteps doyoucompute.Section
+	// Code samples section
+	CodeSamples doyoucompute.Section
+	// Error messages section
+	Errors doyoucompute.Section
+	// Section edits applied once the sections above are in place
+	Edits []sections.Edit
+}
+
+// Option changes the props of a bug report before it is rendered.
+type Option = doyoucompute.OptionBuilder[BugReportProps]
+
+// Defaults returns the props New starts from before applying options.
+func Defaults() BugReportProps {
+	return BugReportProps{
+		Name:               DEFAULT_NAME,
+		Frontmatter:        DefaultFrontMatter(),
+		ExpectedBehavior:   DefaultExpectedBehavior(),
+		ActualBehavior:     DefaultActualBehavior(),
+		EnvironmentDetails: DefaultEnvironmentDetails(),
+		ReproductionSteps:  DefaultStepsToReproduce(),
+		CodeSamples:        DefaultCodeSamples(),
+		Errors:             DefaultErrorMessages(),
+	}
 }
 
 // Overrides the default frontmatter for the document
@@ -48,9 +97,9 @@ type bugReportProps struct {
 //		"labels":    "",
 //		"assignees": "",
 //	}))
-func WithFrontMatter(frontmatter doyoucompute.Frontmatter) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.frontmatter = frontmatter
+func WithFrontMatter(frontmatter doyoucompute.Frontmatter) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.Frontmatter = frontmatter
 
 		return nil, nil
 	}
@@ -64,9 +113,9 @@ func WithFrontMatter(frontmatter doyoucompute.Frontmatter) doyoucompute.OptionBu
 //	section := doyoucompute.NewSection("Expected behavior")
 //	// Add section content...
 //	bugreport.WithExpectedBehavior(section)
-func WithExpectedBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.expectedBehavior = behavior
+func WithExpectedBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.ExpectedBehavior = behavior
 
 		return nil, nil
 	}
@@ -80,9 +129,9 @@ func WithExpectedBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuil
 //	section := doyoucompute.NewSection("Actual behavior")
 //	// Add section content...
 //	bugreport.WithActualBehavior(section)
-func WithActualBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.actualBehavior = behavior
+func WithActualBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.ActualBehavior = behavior
 
 		return nil, nil
 	}
@@ -96,9 +145,9 @@ func WithActualBehavior(behavior doyoucompute.Section) doyoucompute.OptionBuilde
 //	section := doyoucompute.NewSection("Environment")
 //	// Add section content...
 //	bugreport.WithEnvironmentDetails(section)
-func WithEnvironmentDetails(env doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.environmentDetails = env
+func WithEnvironmentDetails(env doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.EnvironmentDetails = env
 
 		return nil, nil
 	}
@@ -114,9 +163,9 @@ func WithEnvironmentDetails(env doyoucompute.Section) doyoucompute.OptionBuilder
 //	list.Append("Run the program")
 //	list.Append("Observe the error")
 //	bugreport.WithReproductionSteps(section)
-func WithReproductionSteps(reproSteps doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.reproductionSteps = reproSteps
+func WithReproductionSteps(reproSteps doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.ReproductionSteps = reproSteps
 
 		return nil, nil
 	}
@@ -130,9 +179,9 @@ func WithReproductionSteps(reproSteps doyoucompute.Section) doyoucompute.OptionB
 //	section := doyoucompute.NewSection("Code samples")
 //	section.WriteCodeBlock("go", []string{"fmt.Println(\"bug\")"}, doyoucompute.Static)
 //	bugreport.WithCodeSamples(section)
-func WithCodeSamples(samples doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.codeSamples = samples
+func WithCodeSamples(samples doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.CodeSamples = samples
 
 		return nil, nil
 	}
@@ -146,9 +195,29 @@ func WithCodeSamples(samples doyoucompute.Section) doyoucompute.OptionBuilder[bu
 //	section := doyoucompute.NewSection("Errors")
 //	// Add section content...
 //	bugreport.WithErrorDetails(section)
-func WithErrorDetails(errDetails doyoucompute.Section) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.errors = errDetails
+func WithErrorDetails(errDetails doyoucompute.Section) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.Errors = errDetails
+
+		return nil, nil
+	}
+}
+
+// WithProjectInfo applies detected project metadata to the document.
+// The environment details section asks for the module version and Go toolchain in use.
+//
+// Example:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	bugreport.WithProjectInfo(info)
+func WithProjectInfo(info metadata.ProjectInfo) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		if info.ModulePath != "" {
+			p.EnvironmentDetails = ProjectEnvironmentDetails(info)
+		}
 
 		return nil, nil
 	}
@@ -159,17 +228,17 @@ func WithErrorDetails(errDetails doyoucompute.Section) doyoucompute.OptionBuilde
 // Example:
 //
 //	bugreport.WithName("foo")
-func WithName(name string) doyoucompute.OptionBuilder[bugReportProps] {
-	return func(p *bugReportProps) (doyoucompute.Finalizer[bugReportProps], error) {
-		p.name = name
+func WithName(name string) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.Name = name
 
-		return func(p *bugReportProps) error {
-			p.frontmatter = doyoucompute.Frontmatter{
+		return func(p *BugReportProps) error {
+			p.Frontmatter = doyoucompute.Frontmatter{
 				Data: map[string]interface{}{
 					"name":      name,
 					"about":     "Report a bug",
 					"title":     "",
-					"labels":    "",
+					"labels":    labels.Join(p.Labels),
 					"assignees": "",
 				},
 			}
@@ -179,6 +248,53 @@ func WithName(name string) doyoucompute.OptionBuilder[bugReportProps] {
 	}
 }
 
+// WithLabels sets the labels applied to new bug reports in the frontmatter.
+// Use labels from a shared labels.Catalogue so the names match the rest of the project.
+//
+// Example:
+//
+//	bugreport.WithLabels(labels.Bug())
+func WithLabels(issueLabels ...labels.Label) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		for _, label := range issueLabels {
+			if err := label.Valid(); err != nil {
+				return nil, err
+			}
+		}
+
+		p.Labels = issueLabels
+
+		return func(p *BugReportProps) error {
+			data := make(map[string]interface{}, len(p.Frontmatter.Data)+1)
+			for key, value := range p.Frontmatter.Data {
+				data[key] = value
+			}
+			data["labels"] = labels.Join(p.Labels)
+
+			p.Frontmatter = doyoucompute.Frontmatter{Data: data}
+
+			return nil
+		}, nil
+	}
+}
+
+// WithSections adds, moves or removes sections once the default sections are in place.
+// Edits naming a section the report does not have fail with sections.ErrUnknownSection.
+//
+// Example:
+//
+//	bugreport.WithSections(
+//		sections.InsertAfter("Actual behavior", screenshotsSection),
+//		sections.Remove("Code Samples"),
+//	)
+func WithSections(edits ...sections.Edit) doyoucompute.OptionBuilder[BugReportProps] {
+	return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		p.Edits = append(p.Edits, edits...)
+
+		return nil, nil
+	}
+}
+
 // DefaultExpectedBehavior returns the default expected behavior section.
 func DefaultExpectedBehavior() doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Expected behavior", func(s *doyoucompute.Section) error {
@@ -212,6 +328,22 @@ func DefaultEnvironmentDetails() doyoucompute.Section {
 	return section
 }
 
+// ProjectEnvironmentDetails returns an environment details section tailored to the project.
+func ProjectEnvironmentDetails(info metadata.ProjectInfo) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Environment details", func(s *doyoucompute.Section) error {
+		comment := fmt.Sprintf("Tell us what version of %s, go version, os, etc.", info.ModulePath)
+		if info.GoVersion != "" {
+			comment = fmt.Sprintf("%s This module requires go %s or later.", comment, info.GoVersion)
+		}
+
+		s.WriteComment(comment)
+
+		return nil
+	})
+
+	return section
+}
+
 // DefaultCodeSamples returns the default code samples section.
 func DefaultCodeSamples() doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Code Samples", func(s *doyoucompute.Section) error {
@@ -269,36 +401,27 @@ func DefaultFrontMatter() doyoucompute.Frontmatter {
 //		bugreport.WithName("API Bug"),
 //		bugreport.WithExpectedBehavior(customSection),
 //	)
-func New(opts ...doyoucompute.OptionBuilder[bugReportProps]) (doyoucompute.Document, error) {
-	props := bugReportProps{
-		name:               DEFAULT_NAME,
-		frontmatter:        DefaultFrontMatter(),
-		expectedBehavior:   DefaultExpectedBehavior(),
-		actualBehavior:     DefaultActualBehavior(),
-		environmentDetails: DefaultEnvironmentDetails(),
-		reproductionSteps:  DefaultStepsToReproduce(),
-		codeSamples:        DefaultCodeSamples(),
-		errors:             DefaultErrorMessages(),
-	}
+func New(opts ...doyoucompute.OptionBuilder[BugReportProps]) (doyoucompute.Document, error) {
+	props := Defaults()
 
 	err := doyoucompute.ApplyOptions(&props, opts...)
 	if err != nil {
 		return doyoucompute.Document{}, err
 	}
 
-	if props.name == "" {
+	if props.Name == "" {
 		return doyoucompute.Document{}, fmt.Errorf("bug report name cannot be empty")
 	}
 
-	return doyoucompute.DocumentFactory(props.name, func(d *doyoucompute.Document) error {
-		d.AddFrontmatter(props.frontmatter)
-		d.AddSection(props.expectedBehavior)
-		d.AddSection(props.actualBehavior)
-		d.AddSection(props.environmentDetails)
-		d.AddSection(props.reproductionSteps)
-		d.AddSection(props.codeSamples)
-		d.AddSection(props.errors)
+	return doyoucompute.DocumentFactory(props.Name, func(d *doyoucompute.Document) error {
+		d.AddFrontmatter(props.Frontmatter)
+		d.AddSection(props.ExpectedBehavior)
+		d.AddSection(props.ActualBehavior)
+		d.AddSection(props.EnvironmentDetails)
+		d.AddSection(props.ReproductionSteps)
+		d.AddSection(props.CodeSamples)
+		d.AddSection(props.Errors)
 
-		return nil
+		return sections.Apply(d, props.Edits...)
 	})
 }
@@ -1,11 +1,16 @@
 package bugreport
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
 func TestBugReport(t *testing.T) {
@@ -22,7 +27,7 @@ func TestBugReport(t *testing.T) {
 
 	tests := []struct {
 		name               string
-		opts               []doyoucompute.OptionBuilder[bugReportProps]
+		opts               []doyoucompute.OptionBuilder[BugReportProps]
 		wantErr            bool
 		wantName           string
 		wantContentCount   int
@@ -42,7 +47,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom name",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithName("Critical Bug Report"),
 			},
 			wantErr:            false,
@@ -54,7 +59,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom frontmatter",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithFrontMatter(*customFrontmatter),
 			},
 			wantErr:            false,
@@ -64,9 +69,54 @@ func TestBugReport(t *testing.T) {
 			wantFrontmatterKey: "labels",
 			wantFrontmatterVal: "bug,critical",
 		},
+		{
+			name: "with labels",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithLabels(labels.Bug(), labels.HelpWanted()),
+			},
+			wantErr:            false,
+			wantName:           "Bug Report",
+			wantContentCount:   6,
+			checkFrontmatter:   true,
+			wantFrontmatterKey: "labels",
+			wantFrontmatterVal: "bug,help wanted",
+		},
+		{
+			name: "labels survive later name change",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithLabels(labels.Bug()),
+				WithName("Crash Report"),
+			},
+			wantErr:            false,
+			wantName:           "Crash Report",
+			wantContentCount:   6,
+			checkFrontmatter:   true,
+			wantFrontmatterKey: "labels",
+			wantFrontmatterVal: "bug",
+		},
+		{
+			name: "labels keep custom frontmatter",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithFrontMatter(*customFrontmatter),
+				WithLabels(labels.Bug()),
+			},
+			wantErr:            false,
+			wantName:           "Bug Report",
+			wantContentCount:   6,
+			checkFrontmatter:   true,
+			wantFrontmatterKey: "assignees",
+			wantFrontmatterVal: "maintainer",
+		},
+		{
+			name: "with invalid label",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithLabels(labels.Label{Name: "bug,critical"}),
+			},
+			wantErr: true,
+		},
 		{
 			name: "with custom expected behavior",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithExpectedBehavior(customSection),
 			},
 			wantErr:          false,
@@ -75,7 +125,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom actual behavior",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithActualBehavior(customSection),
 			},
 			wantErr:          false,
@@ -84,7 +134,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom environment details",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithEnvironmentDetails(customSection),
 			},
 			wantErr:          false,
@@ -93,7 +143,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom reproduction steps",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithReproductionSteps(customSection),
 			},
 			wantErr:          false,
@@ -102,7 +152,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom code samples",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithCodeSamples(customSection),
 			},
 			wantErr:          false,
@@ -111,7 +161,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with custom error details",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithErrorDetails(customSection),
 			},
 			wantErr:          false,
@@ -120,7 +170,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with multiple options",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithName("Production Bug"),
 				WithExpectedBehavior(customSection),
 				WithActualBehavior(customSection),
@@ -131,7 +181,7 @@ func TestBugReport(t *testing.T) {
 		},
 		{
 			name: "with all options",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithName("Complete Bug Report"),
 				WithFrontMatter(*customFrontmatter),
 				WithExpectedBehavior(customSection),
@@ -198,7 +248,7 @@ func TestBugReport(t *testing.T) {
 func TestBugReportContent(t *testing.T) {
 	tests := []struct {
 		name            string
-		opts            []doyoucompute.OptionBuilder[bugReportProps]
+		opts            []doyoucompute.OptionBuilder[BugReportProps]
 		wantContains    []string
 		wantNotContains []string
 	}{
@@ -216,7 +266,7 @@ func TestBugReportContent(t *testing.T) {
 		},
 		{
 			name: "custom section replaces default",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithExpectedBehavior(func() doyoucompute.Section {
 					s := doyoucompute.NewSection("My Custom Expected")
 					s.WriteParagraph().Text("Custom expected behavior")
@@ -232,6 +282,31 @@ func TestBugReportContent(t *testing.T) {
 				"What should happen?",
 			},
 		},
+		{
+			name: "project info tailors environment details",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithProjectInfo(metadata.ProjectInfo{
+					ModulePath: "github.com/user/project",
+					GoVersion:  "1.23",
+				}),
+			},
+			wantContains: []string{
+				"Tell us what version of github.com/user/project, go version, os, etc.",
+				"requires go 1.23 or later",
+			},
+			wantNotContains: []string{
+				"package version",
+			},
+		},
+		{
+			name: "project info without module keeps default",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithProjectInfo(metadata.ProjectInfo{}),
+			},
+			wantContains: []string{
+				"Tell us what go version, os, package version, etc.",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -265,13 +340,13 @@ func TestBugReportContent(t *testing.T) {
 func TestBugReportValidation(t *testing.T) {
 	tests := []struct {
 		name    string
-		opts    []doyoucompute.OptionBuilder[bugReportProps]
+		opts    []doyoucompute.OptionBuilder[BugReportProps]
 		wantErr bool
 		errMsg  string
 	}{
 		{
 			name: "empty name should error",
-			opts: []doyoucompute.OptionBuilder[bugReportProps]{
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
 				WithName(""),
 			},
 			wantErr: true,
@@ -366,3 +441,129 @@ func TestDefaultFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestBugReportSections(t *testing.T) {
+	screenshots := doyoucompute.NewSection("Screenshots")
+	screenshots.WriteComment("Drag screenshots of the problem here.")
+
+	tests := []struct {
+		name         string
+		opts         []doyoucompute.OptionBuilder[BugReportProps]
+		wantSections []string
+		wantErr      string
+	}{
+		{
+			name:         "default sections",
+			wantSections: []string{"Expected behavior", "Actual behavior", "Environment details", "Steps to reproduce", "Code Samples", "Error Messages"},
+		},
+		{
+			name: "insert and remove",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithSections(
+					sections.InsertAfter("Actual behavior", screenshots),
+					sections.Remove("Code Samples"),
+				),
+			},
+			wantSections: []string{"Expected behavior", "Actual behavior", "Screenshots", "Environment details", "Steps to reproduce", "Error Messages"},
+		},
+		{
+			name: "edits from repeated options apply in order",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithSections(sections.Append(screenshots)),
+				WithSections(sections.Remove("Error Messages")),
+			},
+			wantSections: []string{"Expected behavior", "Actual behavior", "Environment details", "Steps to reproduce", "Code Samples", "Screenshots"},
+		},
+		{
+			name: "unknown section",
+			opts: []doyoucompute.OptionBuilder[BugReportProps]{
+				WithSections(sections.Remove("Logs")),
+			},
+			wantErr: `"Logs" in Bug Report`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.opts...)
+			if tt.wantErr != "" {
+				if !errors.Is(err, sections.ErrUnknownSection) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should be sections.ErrUnknownSection containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got := sections.Names(doc); !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("sections.Names() = %q, want %q", got, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestCustomOption(t *testing.T) {
+	defaults := Defaults()
+	appendNote := func(note string) Option {
+		return func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+			if p.ActualBehavior.Name != defaults.ActualBehavior.Name {
+				return nil, fmt.Errorf("option saw %q, want the default %q", p.ActualBehavior.Name, defaults.ActualBehavior.Name)
+			}
+
+			p.ActualBehavior.WriteParagraph().Text(note)
+
+			return nil, nil
+		}
+	}
+	failing := func(p *BugReportProps) (doyoucompute.Finalizer[BugReportProps], error) {
+		return nil, errors.New("custom option failed")
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantContains string
+		wantErr      string
+	}{
+		{
+			name:         "custom option sees the defaults",
+			opts:         []Option{appendNote("A custom note")},
+			wantContains: "A custom note",
+		},
+		{
+			name:         "custom and built-in options mix",
+			opts:         []Option{WithName("Crash report"), appendNote("Another note")},
+			wantContains: "Another note",
+		},
+		{
+			name:    "custom option errors are returned",
+			opts:    []Option{failing},
+			wantErr: "custom option failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			rendered, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			if !strings.Contains(rendered, tt.wantContains) {
+				t.Errorf("renderer.Render() = %q, should contain %q", rendered, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,886 @@
+// Package config generates documents from a declarative YAML file.
+//
+// A config file names the templates to generate, the option values for each and
+// the paths they are written to, so a project can keep its community files up to
+// date without writing Go. Every value maps onto one of the templates' With*
+// options. The file is checked against the shape of the Config type before
+// anything is built, and every problem is reported with its line and column.
+// The same shape is published as a JSON Schema, see Schema, so editors can
+// complete and check the file as it is written.
+//
+// Example .doyoucompute.yaml:
+//
+//	# yaml-language-server: $schema=https://raw.githubusercontent.com/MoonMoon1919/doyoucompute-templates/main/pkg/config/doyoucompute.schema.json
+//	templates:
+//	  readme:
+//	    name: widget
+//	    intro: Widgets for everyone.
+//	    features: |
+//	      - Fast
+//	      - Small
+//	    quickstart: |
+//	      Run `go get github.com/acme/widget`.
+//	    license:
+//	      name: MIT
+//	  contributing:
+//	    project_url: https://github.com/acme/widget
+//	    preset: internal
+//	    disable: [Writing documentation]
+//	  bugreport:
+//	    labels: [bug]
+//	    sections:
+//	      - name: Screenshots
+//	        markdown: Drag screenshots of the problem here.
+//	derived:
+//	  - template: internal-bugreport
+//	    base: bugreport
+//	    path: .github/ISSUE_TEMPLATE/internal_bug_report.md
+//	    name: Internal bug report
+//	    frontmatter:
+//	      - key: assignees
+//	        value: platform-oncall
+//
+// Derived templates are built by the templates package, see templates.Configured.
+//
+// Basic usage:
+//
+//	cfg, err := config.Load(os.DirFS("."), config.DEFAULT_PATH)
+//	if err != nil {
+//		// handle error
+//	}
+//	ts, err := templates.Configured(cfg)
+//	if err != nil {
+//		// handle error
+//	}
+//	docs, err := templates.Documents(cfg, ts...)
+//	if err != nil {
+//		// handle error
+//	}
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/files"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/pullrequest"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+	"gopkg.in/yaml.v3"
+)
+
+// DEFAULT_PATH is the conventional location of the config file, at the repository root.
+const DEFAULT_PATH = ".doyoucompute.yaml"
+
+// Config declares the documents to generate.
+type Config struct {
+	// Templates holds one entry per template to generate
+	Templates Templates `yaml:"templates" config:"required"`
+	// Derived declares variants of the templates, each generated alongside them, see templates.Derive
+	Derived []Derived `yaml:"derived,omitempty"`
+
+	file      string
+	positions *checker
+}
+
+// Templates selects the templates to generate. Templates left out are not generated.
+type Templates struct {
+	// Readme generates the README, see the readme package
+	Readme *Readme `yaml:"readme,omitempty"`
+	// Contributing generates the contributing guide, see the contributing package
+	Contributing *Contributing `yaml:"contributing,omitempty"`
+	// PullRequest generates the pull request template, see the pullrequest package
+	PullRequest *PullRequest `yaml:"pullrequest,omitempty"`
+	// BugReport generates the bug report issue template, see the bugreport package
+	BugReport *BugReport `yaml:"bugreport,omitempty"`
+}
+
+// Output holds the settings every template accepts.
+type Output struct {
+	// Path overrides the template's DEFAULT_PATH
+	Path string `yaml:"path,omitempty"`
+	// Name overrides the document name
+	Name string `yaml:"name,omitempty"`
+	// Disable lists the headings of sections to leave out; every section with a listed heading
+	// is left out, at any depth
+	Disable []string `yaml:"disable,omitempty"`
+	// Sections are appended after the template's own sections
+	Sections []Section `yaml:"sections,omitempty"`
+	// Skip leaves the document out of the generated files, while templates derived from it
+	// are still built from its settings
+	Skip bool `yaml:"skip,omitempty"`
+}
+
+// Derived declares a template derived from a built-in template or an earlier derived one.
+// Its path, name, sections and disable settings change the document the base builds.
+type Derived struct {
+	Output `yaml:",inline"`
+	// Template names the derived template; it must differ from every other template name
+	Template string `yaml:"template,omitempty" config:"required"`
+	// Base names the template it is derived from
+	Base string `yaml:"base,omitempty" config:"required"`
+	// Description says in one sentence what the document is for
+	Description string `yaml:"description,omitempty"`
+	// Frontmatter values are set over those of the base
+	Frontmatter []FrontmatterValue `yaml:"frontmatter,omitempty"`
+}
+
+// FrontmatterValue is one frontmatter key set by a derived template.
+type FrontmatterValue struct {
+	// Key is the frontmatter key, e.g. "assignees"
+	Key string `yaml:"key,omitempty" config:"required"`
+	// Value is the value written for the key
+	Value string `yaml:"value,omitempty"`
+}
+
+// Section is an extra section written in markdown.
+type Section struct {
+	// Name is the section heading
+	Name string `yaml:"name,omitempty" config:"required"`
+	// Markdown is the body of the section, rendered as is
+	Markdown string `yaml:"markdown,omitempty" config:"required"`
+}
+
+// License names the project's license. Maps onto readme.WithLicense.
+type License struct {
+	// Name is the license name, e.g. "MIT"
+	Name string `yaml:"name,omitempty" config:"required"`
+	// Path is the link to the license file; defaults to ./LICENSE
+	Path string `yaml:"path,omitempty"`
+}
+
+// Readme configures the readme template.
+// The name, features and quickstart are required, as readme.New requires them.
+type Readme struct {
+	Output `yaml:",inline"`
+	// Intro is the introduction paragraph
+	Intro string `yaml:"intro,omitempty"`
+	// Features is the body of the features section
+	Features string `yaml:"features,omitempty" config:"required"`
+	// QuickStart is the body of the quickstart section
+	QuickStart string `yaml:"quickstart,omitempty" config:"required"`
+	// License maps onto readme.WithLicense
+	License *License `yaml:"license,omitempty"`
+	// Contributing maps onto readme.WithContributing
+	Contributing string `yaml:"contributing,omitempty"`
+	// TableOfContents maps onto readme.WithTableOfContents
+	TableOfContents int `yaml:"table_of_contents,omitempty"`
+}
+
+// Preset is the name of a contributing.Preset.
+type Preset string
+
+// Values returns the names of every contributing preset.
+func (Preset) Values() []string {
+	return []string{contributing.OpenSourcePreset.String(), contributing.InternalPreset.String()}
+}
+
+// preset returns the contributing preset with the name.
+func (p Preset) preset() contributing.Preset {
+	if string(p) == contributing.InternalPreset.String() {
+		return contributing.InternalPreset
+	}
+
+	return contributing.OpenSourcePreset
+}
+
+// Profile maps onto contributing.Profile.
+type Profile struct {
+	// Name is the display name of the ecosystem
+	Name string `yaml:"name,omitempty" config:"required"`
+	// Install contains the commands that install dependencies
+	Install []string `yaml:"install,omitempty"`
+	// Test contains the commands that run the test suite
+	Test []string `yaml:"test,omitempty" config:"required"`
+	// Lint contains the commands that run linters and formatters
+	Lint []string `yaml:"lint,omitempty"`
+}
+
+// Branching is the name of a built-in contributing.BranchScheme.
+type Branching string
+
+// Values returns the names of the built-in branch schemes.
+func (Branching) Values() []string {
+	return []string{"free-form", "typed"}
+}
+
+// scheme returns the branch scheme with the name.
+func (b Branching) scheme() contributing.BranchScheme {
+	if b == "typed" {
+		return contributing.TypedBranchScheme()
+	}
+
+	return contributing.DefaultBranchScheme()
+}
+
+// Commits is the name of a built-in contributing.CommitConvention.
+type Commits string
+
+// Values returns the names of the built-in commit conventions.
+func (Commits) Values() []string {
+	return []string{"free-form", "conventional", "gitmoji"}
+}
+
+// convention returns the commit convention with the name.
+func (c Commits) convention() contributing.CommitConvention {
+	switch c {
+	case "conventional":
+		return contributing.ConventionalCommits()
+	case "gitmoji":
+		return contributing.Gitmoji()
+	}
+
+	return contributing.FreeFormCommits()
+}
+
+// Merge is the name of a contributing.MergePolicy.
+type Merge string
+
+// Values returns the names of the merge policies.
+func (Merge) Values() []string {
+	return []string{"squash", "merge", "rebase"}
+}
+
+// policy returns the merge policy with the name.
+func (m Merge) policy() contributing.MergePolicy {
+	switch m {
+	case "squash":
+		return contributing.SquashMerge
+	case "merge":
+		return contributing.MergeCommit
+	case "rebase":
+		return contributing.RebaseMerge
+	}
+
+	return contributing.UnspecifiedMerge
+}
+
+// CLA maps onto contributing.WithCLA.
+type CLA struct {
+	// Url links to the agreement contributors sign
+	Url string `yaml:"url,omitempty" config:"required"`
+	// Process explains how to sign; defaults to contributing.DefaultCLAProcess
+	Process string `yaml:"process,omitempty"`
+}
+
+// Review maps onto contributing.WithReviewProcess. Fields left out take their value
+// from contributing.DefaultReviewProcess.
+type Review struct {
+	// RequiredApprovals is the number of approving reviews needed before merging
+	RequiredApprovals *int `yaml:"required_approvals,omitempty"`
+	// CodeOwners requests reviewers from a CODEOWNERS file
+	CodeOwners bool `yaml:"code_owners,omitempty"`
+	// Turnaround sets expectations for how quickly reviews happen
+	Turnaround string `yaml:"turnaround,omitempty"`
+	// ReRequest explains how to ask for another review after addressing feedback
+	ReRequest string `yaml:"re_request,omitempty"`
+}
+
+// Release maps onto contributing.WithReleaseProcess. Fields left out take their value
+// from contributing.DefaultReleaseProcess.
+type Release struct {
+	// Versioning is the name of the versioning scheme, e.g. "Semantic Versioning"
+	Versioning string `yaml:"versioning,omitempty"`
+	// VersioningUrl links to the versioning scheme's specification; the default link is only
+	// kept when versioning is left out too
+	VersioningUrl string `yaml:"versioning_url,omitempty"`
+	// Releasers describes who cuts releases and how
+	Releasers string `yaml:"releasers,omitempty"`
+	// Changelog describes what contributors need to do for the changelog
+	Changelog string `yaml:"changelog,omitempty"`
+}
+
+// Contributing configures the contributing template.
+// Makefile tasks have no setting, as contributing.WithMakefileTasks reads them from the
+// repository, and sections replaced with the With<Section> options are written with
+// sections and disable instead.
+type Contributing struct {
+	Output `yaml:",inline"`
+	// ProjectUrl is the projectUrl argument of contributing.New
+	ProjectUrl string `yaml:"project_url,omitempty" config:"required"`
+	// IssueTrackerUrl is the issueTrackerUrl argument of contributing.New
+	IssueTrackerUrl string `yaml:"issue_tracker_url,omitempty"`
+	// Preset maps onto contributing.WithPreset
+	Preset Preset `yaml:"preset,omitempty"`
+	// DefaultBranch maps onto contributing.WithDefaultBranch
+	DefaultBranch string `yaml:"default_branch,omitempty"`
+	// Profile maps onto contributing.WithProfile
+	Profile *Profile `yaml:"profile,omitempty"`
+	// SignOff maps onto contributing.WithSignOff
+	SignOff bool `yaml:"sign_off,omitempty"`
+	// DCO maps onto contributing.WithDCO
+	DCO bool `yaml:"dco,omitempty"`
+	// LicenseID maps onto contributing.WithLicenseID
+	LicenseID string `yaml:"license_id,omitempty"`
+	// LicensePath is the license file linked with license_id, ./LICENSE when left out
+	LicensePath string `yaml:"license_path,omitempty"`
+	// CLA maps onto contributing.WithCLA
+	CLA *CLA `yaml:"cla,omitempty"`
+	// Branching maps onto contributing.WithBranchScheme
+	Branching Branching `yaml:"branching,omitempty"`
+	// Commits maps onto contributing.WithCommitConvention
+	Commits Commits `yaml:"commits,omitempty"`
+	// Merge maps onto contributing.WithMergePolicy
+	Merge Merge `yaml:"merge,omitempty"`
+	// Review maps onto contributing.WithReviewProcess
+	Review *Review `yaml:"review,omitempty"`
+	// Release maps onto contributing.WithReleaseProcess
+	Release *Release `yaml:"release,omitempty"`
+	// TaskLabels maps onto contributing.WithTaskLabels
+	TaskLabels []Label `yaml:"task_labels,omitempty"`
+	// TableOfContents maps onto contributing.WithTableOfContents
+	TableOfContents int `yaml:"table_of_contents,omitempty"`
+}
+
+// PullRequest configures the pull request template.
+type PullRequest struct {
+	Output `yaml:",inline"`
+}
+
+// Label is the name of a label in labels.Default.
+type Label string
+
+// Values returns the names of the labels in the default catalogue.
+func (Label) Values() []string {
+	return labels.Default().Names()
+}
+
+// BugReport configures the bug report template.
+type BugReport struct {
+	Output `yaml:",inline"`
+	// About is the description of the template in the frontmatter
+	About string `yaml:"about,omitempty"`
+	// Title is the default issue title in the frontmatter
+	Title string `yaml:"title,omitempty"`
+	// Labels maps onto bugreport.WithLabels
+	Labels []Label `yaml:"labels,omitempty"`
+	// Assignees are the users new issues are assigned to in the frontmatter
+	Assignees []string `yaml:"assignees,omitempty"`
+}
+
+// Parse reads a config from YAML, returning a *ValidationError listing every
+// value that does not match the shape of Config.
+func Parse(data []byte) (*Config, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("could not parse config: %w", err)
+	}
+
+	positions := newChecker()
+
+	if len(root.Content) == 0 {
+		return nil, &ValidationError{Errors: []FieldError{{Position: Position{Line: 1, Column: 1}, Message: "config is empty"}}}
+	}
+
+	document := root.Content[0]
+	positions.positions[""] = Position{Line: document.Line, Column: document.Column}
+
+	positions.check(document, reflect.TypeOf(Config{}), "")
+	if len(positions.errors) > 0 {
+		return nil, &ValidationError{Errors: positions.sorted()}
+	}
+
+	cfg := &Config{positions: positions}
+	if err := document.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("could not decode config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Load reads and parses the config file name in fsys.
+// Validation errors, including those returned later by templates.Documents, name the file.
+//
+// Example:
+//
+//	cfg, err := config.Load(os.DirFS("."), config.DEFAULT_PATH)
+func Load(fsys fs.FS, name string) (*Config, error) {
+	data, err := files.Read(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := Parse(data)
+
+	var invalid *ValidationError
+	if errors.As(err, &invalid) {
+		invalid.File = name
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.file = name
+
+	return cfg, nil
+}
+
+// Encode returns the config as YAML, starting with a modeline that points editors at the schema.
+// The result parses back to the same config.
+func (c *Config) Encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("# yaml-language-server: $schema=" + SCHEMA_ID + "\n")
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("could not encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("could not encode config: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// ErrorAt returns err as a FieldError positioned at path, or its closest parent, so
+// errors from building a template point at the setting that caused them.
+// Errors that already are a FieldError are returned unchanged.
+func (c *Config) ErrorAt(path string, err error) FieldError {
+	var fieldErr FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr
+	}
+
+	var position Position
+	if c.positions != nil {
+		position = c.positions.position(path)
+	}
+
+	return FieldError{Position: position, Path: path, Message: err.Error()}
+}
+
+// ErrNotConfigured is returned by Build for a template the config leaves out.
+var ErrNotConfigured = errors.New("template is not configured")
+
+// TemplateNames returns the keys of the built-in templates under templates, in the order
+// templates.Builtin returns them.
+func TemplateNames() []string {
+	return []string{"readme", "contributing", "pullrequest", "bugreport"}
+}
+
+// DefaultPaths returns the key of each built-in template mapped to its package's DEFAULT_PATH.
+func DefaultPaths() map[string]string {
+	return map[string]string{
+		"readme":       readme.DEFAULT_PATH,
+		"contributing": contributing.DEFAULT_PATH,
+		"pullrequest":  pullrequest.DEFAULT_PATH,
+		"bugreport":    bugreport.DEFAULT_PATH,
+	}
+}
+
+// Build builds the built-in template with the key name under templates.
+// Returns ErrNotConfigured when the config leaves the template out, and a FieldError
+// positioned at the offending setting when the settings are invalid.
+//
+// Example:
+//
+//	doc, err := cfg.Build("readme")
+func (c *Config) Build(name string) (doyoucompute.Document, error) {
+	var configured bool
+	var build func() (doyoucompute.Document, error)
+
+	switch name {
+	case "readme":
+		configured, build = c.Templates.Readme != nil, c.readme
+	case "contributing":
+		configured, build = c.Templates.Contributing != nil, c.contributing
+	case "pullrequest":
+		configured, build = c.Templates.PullRequest != nil, c.pullRequest
+	case "bugreport":
+		configured, build = c.Templates.BugReport != nil, c.bugReport
+	default:
+		return doyoucompute.Document{}, fmt.Errorf("unknown template %q, expected one of %s", name, strings.Join(TemplateNames(), ", "))
+	}
+
+	if !configured {
+		return doyoucompute.Document{}, fmt.Errorf("%w: %s", ErrNotConfigured, name)
+	}
+
+	return build()
+}
+
+// Path returns the path configured for the built-in template with the key name,
+// or an empty string when it is rendered to its package's DEFAULT_PATH.
+func (c *Config) Path(name string) string {
+	if output := c.output(name); output != nil {
+		return output.Path
+	}
+
+	return ""
+}
+
+// Skipped reports whether the built-in or derived template named name is left out of the
+// generated files by its skip setting.
+func (c *Config) Skipped(name string) bool {
+	if output := c.output(name); output != nil {
+		return output.Skip
+	}
+
+	for _, derived := range c.Derived {
+		if derived.Template == name {
+			return derived.Skip
+		}
+	}
+
+	return false
+}
+
+// WithDefaults returns a copy of the config in which every built-in template the config
+// leaves out is configured with empty settings, so templates derived from one build
+// without it being listed. The settings of the templates the config lists are shared.
+func (c *Config) WithDefaults() *Config {
+	defaulted := Config{}
+	if c != nil {
+		defaulted = *c
+	}
+
+	if defaulted.Templates.Readme == nil {
+		defaulted.Templates.Readme = &Readme{}
+	}
+	if defaulted.Templates.Contributing == nil {
+		defaulted.Templates.Contributing = &Contributing{}
+	}
+	if defaulted.Templates.PullRequest == nil {
+		defaulted.Templates.PullRequest = &PullRequest{}
+	}
+	if defaulted.Templates.BugReport == nil {
+		defaulted.Templates.BugReport = &BugReport{}
+	}
+
+	return &defaulted
+}
+
+// output returns the shared settings of the built-in template with the key name, or nil
+// when the config leaves it out.
+func (c *Config) output(name string) *Output {
+	switch {
+	case name == "readme" && c.Templates.Readme != nil:
+		return &c.Templates.Readme.Output
+	case name == "contributing" && c.Templates.Contributing != nil:
+		return &c.Templates.Contributing.Output
+	case name == "pullrequest" && c.Templates.PullRequest != nil:
+		return &c.Templates.PullRequest.Output
+	case name == "bugreport" && c.Templates.BugReport != nil:
+		return &c.Templates.BugReport.Output
+	}
+
+	return nil
+}
+
+// File returns the name of the file the config was loaded from, if any.
+func (c *Config) File() string {
+	return c.file
+}
+
+// edits returns the shared settings as the edits passed to a template's WithSections
+// option: extra sections are appended, then disabled sections removed. Templates insert
+// their table of contents after their edits, so it lists the final set of sections.
+// Errors are positioned at the setting each edit comes from.
+func (c *Config) edits(path string, extra []Section, disable []string) []sections.Edit {
+	edits := make([]sections.Edit, 0, len(extra)+len(disable))
+
+	for idx, section := range extra {
+		edits = append(edits, c.editAt(fmt.Sprintf("%s.sections[%d]", path, idx), sections.Append(section.Build())))
+	}
+	for idx, name := range disable {
+		edits = append(edits, c.editAt(fmt.Sprintf("%s.disable[%d]", path, idx), sections.RemoveAll(name)))
+	}
+
+	return edits
+}
+
+// editAt returns edit with its error positioned at path.
+func (c *Config) editAt(path string, edit sections.Edit) sections.Edit {
+	return func(d *doyoucompute.Document) error {
+		if err := edit(d); err != nil {
+			return c.ErrorAt(path, err)
+		}
+
+		return nil
+	}
+}
+
+// labels looks up the label names at path in the default catalogue.
+func (c *Config) labels(path string, names []Label) ([]labels.Label, error) {
+	values := make([]string, len(names))
+	for idx, label := range names {
+		values[idx] = string(label)
+	}
+
+	found, err := labels.Default().Lookup(values...)
+	if err != nil {
+		return nil, c.ErrorAt(path, err)
+	}
+
+	return found, nil
+}
+
+func (c *Config) readme() (doyoucompute.Document, error) {
+	const path = "templates.readme"
+	cfg := c.Templates.Readme
+
+	if cfg.Name == "" {
+		return doyoucompute.Document{}, c.ErrorAt(path, errors.New(`missing required field "name"`))
+	}
+	if cfg.TableOfContents < 0 {
+		return doyoucompute.Document{}, c.ErrorAt(path+".table_of_contents", fmt.Errorf("table of contents depth must be at least 1, got %d", cfg.TableOfContents))
+	}
+
+	intro := doyoucompute.NewParagraph()
+	if cfg.Intro != "" {
+		intro.Text(strings.TrimSpace(cfg.Intro))
+	}
+
+	opts := []readme.Option{readme.WithName(cfg.Name)}
+
+	if cfg.License != nil {
+		licensePath := cfg.License.Path
+		if licensePath == "" {
+			licensePath = "./LICENSE"
+		}
+		opts = append(opts, readme.WithLicense(cfg.License.Name, licensePath))
+	}
+	if cfg.Contributing != "" {
+		opts = append(opts, readme.WithContributing(cfg.Contributing))
+	}
+	if cfg.TableOfContents > 0 {
+		opts = append(opts, readme.WithTableOfContents(cfg.TableOfContents))
+	}
+
+	// Extra sections are placed by readme.New, ahead of the contributing and license sections
+	opts = append(opts, readme.WithSections(c.edits(path, nil, cfg.Disable)...))
+
+	sections := make([]doyoucompute.Section, len(cfg.Sections))
+	for idx, section := range cfg.Sections {
+		sections[idx] = section.Build()
+	}
+
+	doc, err := readme.New(
+		readme.ReadmeProps{
+			Name:       cfg.Name,
+			Intro:      *intro,
+			Features:   markdownSection("Features", cfg.Features),
+			QuickStart: markdownSection("Quickstart", cfg.QuickStart),
+		},
+		sections,
+		opts...,
+	)
+	if err != nil {
+		return doyoucompute.Document{}, c.ErrorAt(path, err)
+	}
+
+	return doc, nil
+}
+
+func (c *Config) contributing() (doyoucompute.Document, error) {
+	const path = "templates.contributing"
+	cfg := c.Templates.Contributing
+
+	if cfg.TableOfContents < 0 {
+		return doyoucompute.Document{}, c.ErrorAt(path+".table_of_contents", fmt.Errorf("table of contents depth must be at least 1, got %d", cfg.TableOfContents))
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = contributing.DefaultName()
+	}
+
+	opts := []contributing.Option{contributing.WithName(name)}
+
+	if cfg.Profile != nil {
+		profile := contributing.Profile{Name: cfg.Profile.Name, Install: cfg.Profile.Install, Test: cfg.Profile.Test, Lint: cfg.Profile.Lint}
+		if err := profile.Valid(); err != nil {
+			return doyoucompute.Document{}, c.ErrorAt(path+".profile", err)
+		}
+		opts = append(opts, contributing.WithProfile(profile))
+	}
+	if cfg.Preset != "" {
+		opts = append(opts, contributing.WithPreset(cfg.Preset.preset()))
+	}
+	if cfg.DefaultBranch != "" {
+		opts = append(opts, contributing.WithDefaultBranch(cfg.DefaultBranch))
+	}
+	if cfg.SignOff {
+		opts = append(opts, contributing.WithSignOff())
+	}
+	if cfg.DCO {
+		opts = append(opts, contributing.WithDCO())
+	}
+	if cfg.LicensePath != "" && cfg.LicenseID == "" {
+		return doyoucompute.Document{}, c.ErrorAt(path+".license_path", errors.New(`requires "license_id" to be set`))
+	}
+	if cfg.LicenseID != "" {
+		opts = append(opts, contributing.WithLicenseID(cfg.LicenseID, cfg.LicensePath))
+	}
+	if cfg.CLA != nil {
+		opts = append(opts, contributing.WithCLA(cfg.CLA.Url, cfg.CLA.Process))
+	}
+	if cfg.Branching != "" {
+		opts = append(opts, contributing.WithBranchScheme(cfg.Branching.scheme()))
+	}
+	if cfg.Commits != "" {
+		opts = append(opts, contributing.WithCommitConvention(cfg.Commits.convention()))
+	}
+	if cfg.Merge != "" {
+		opts = append(opts, contributing.WithMergePolicy(cfg.Merge.policy()))
+	}
+	if cfg.Review != nil {
+		opts = append(opts, contributing.WithReviewProcess(cfg.Review.process()))
+	}
+	if cfg.Release != nil {
+		opts = append(opts, contributing.WithReleaseProcess(cfg.Release.process()))
+	}
+	if len(cfg.TaskLabels) > 0 {
+		taskLabels, err := c.labels(path+".task_labels", cfg.TaskLabels)
+		if err != nil {
+			return doyoucompute.Document{}, err
+		}
+		opts = append(opts, contributing.WithTaskLabels(taskLabels...))
+	}
+	if cfg.TableOfContents > 0 {
+		opts = append(opts, contributing.WithTableOfContents(cfg.TableOfContents))
+	}
+
+	opts = append(opts, contributing.WithSections(c.edits(path, cfg.Sections, cfg.Disable)...))
+
+	doc, err := contributing.New(cfg.ProjectUrl, cfg.IssueTrackerUrl, opts...)
+	if err != nil {
+		return doyoucompute.Document{}, c.ErrorAt(path, err)
+	}
+
+	return doc, nil
+}
+
+// process returns the review process, filling in the fields left out from the default.
+func (r Review) process() contributing.ReviewProcess {
+	review := contributing.DefaultReviewProcess()
+
+	if r.RequiredApprovals != nil {
+		review.RequiredApprovals = *r.RequiredApprovals
+	}
+	review.CodeOwners = r.CodeOwners
+	if r.Turnaround != "" {
+		review.Turnaround = r.Turnaround
+	}
+	if r.ReRequest != "" {
+		review.ReRequest = r.ReRequest
+	}
+
+	return review
+}
+
+// process returns the release process, filling in the fields left out from the default.
+func (r Release) process() contributing.ReleaseProcess {
+	release := contributing.DefaultReleaseProcess()
+
+	if r.Versioning != "" {
+		release.Versioning = r.Versioning
+		release.VersioningUrl = r.VersioningUrl
+	} else if r.VersioningUrl != "" {
+		release.VersioningUrl = r.VersioningUrl
+	}
+	if r.Releasers != "" {
+		release.Releasers = r.Releasers
+	}
+	if r.Changelog != "" {
+		release.Changelog = r.Changelog
+	}
+
+	return release
+}
+
+func (c *Config) pullRequest() (doyoucompute.Document, error) {
+	const path = "templates.pullrequest"
+	cfg := c.Templates.PullRequest
+
+	name := cfg.Name
+	if name == "" {
+		name = pullrequest.DefaultName()
+	}
+
+	doc, err := pullrequest.New(
+		pullrequest.WithName(name),
+		pullrequest.WithSections(c.edits(path, cfg.Sections, cfg.Disable)...),
+	)
+	if err != nil {
+		return doyoucompute.Document{}, c.ErrorAt(path, err)
+	}
+
+	return doc, nil
+}
+
+func (c *Config) bugReport() (doyoucompute.Document, error) {
+	const path = "templates.bugreport"
+	cfg := c.Templates.BugReport
+
+	name := cfg.Name
+	if name == "" {
+		name = bugreport.DEFAULT_NAME
+	}
+
+	// WithName resets the frontmatter, so it comes before the frontmatter overrides
+	opts := []bugreport.Option{bugreport.WithName(name)}
+
+	if cfg.About != "" || cfg.Title != "" || len(cfg.Assignees) > 0 {
+		data := bugreport.DefaultFrontMatter().Data
+		data["name"] = name
+
+		if cfg.About != "" {
+			data["about"] = cfg.About
+		}
+		if cfg.Title != "" {
+			data["title"] = cfg.Title
+		}
+		if len(cfg.Assignees) > 0 {
+			data["assignees"] = strings.Join(cfg.Assignees, ",")
+		}
+
+		opts = append(opts, bugreport.WithFrontMatter(*doyoucompute.NewFrontmatter(data)))
+	}
+
+	if len(cfg.Labels) > 0 {
+		issueLabels, err := c.labels(path+".labels", cfg.Labels)
+		if err != nil {
+			return doyoucompute.Document{}, err
+		}
+
+		opts = append(opts, bugreport.WithLabels(issueLabels...))
+	}
+
+	opts = append(opts, bugreport.WithSections(c.edits(path, cfg.Sections, cfg.Disable)...))
+
+	doc, err := bugreport.New(opts...)
+	if err != nil {
+		return doyoucompute.Document{}, c.ErrorAt(path, err)
+	}
+
+	return doc, nil
+}
+
+// markdownSection returns a section whose body is rendered as is.
+func markdownSection(name, markdown string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory(name, func(s *doyoucompute.Section) error {
+		if body := strings.TrimSpace(markdown); body != "" {
+			s.WriteIntro().Text(body)
+		}
+
+		return nil
+	})
+
+	return section
+}
+
+// Build returns the section with its markdown body rendered as is.
+func (s Section) Build() doyoucompute.Section {
+	return markdownSection(s.Name, s.Markdown)
+}
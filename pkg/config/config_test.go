@@ -0,0 +1,311 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+)
+
+const FULL_CONFIG = `templates:
+  readme:
+    name: widget
+    intro: Widgets for everyone.
+    features: |
+      - Fast
+      - Small
+    quickstart: |
+      Run ` + "`go get github.com/acme/widget`" + `.
+    license:
+      name: MIT
+    sections:
+      - name: Usage
+        markdown: Call widget.New.
+    table_of_contents: 1
+  contributing:
+    path: docs/CONTRIBUTING.md
+    project_url: https://github.com/acme/widget
+    preset: internal
+    default_branch: develop
+    sign_off: true
+    dco: true
+    disable: [Writing documentation]
+  pullrequest:
+    name: Change request
+    sections:
+      - name: Security impact
+        markdown: Does this change how credentials are handled?
+  bugreport:
+    about: Report a widget bug
+    labels: [bug]
+    assignees: [octocat, hubot]
+    disable: [Code Samples]
+`
+
+func render(t *testing.T, doc doyoucompute.Document) string {
+	t.Helper()
+
+	content, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	return content
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		template string
+		wantDoc  string
+		wantPath string
+		wantErr  error
+		wantMsg  string
+	}{
+		{
+			name:     "configured path",
+			config:   FULL_CONFIG,
+			template: "contributing",
+			wantDoc:  "Contributing",
+			wantPath: "docs/CONTRIBUTING.md",
+		},
+		{
+			name:     "default path",
+			config:   FULL_CONFIG,
+			template: "pullrequest",
+			wantDoc:  "Change request",
+		},
+		{
+			name:     "not configured",
+			config:   "templates:\n  bugreport: {}\n",
+			template: "readme",
+			wantErr:  ErrNotConfigured,
+		},
+		{
+			name:     "license path without license id",
+			config:   "templates:\n  contributing:\n    project_url: https://github.com/acme/widget\n    license_path: ./LICENSE.md\n",
+			template: "contributing",
+			wantMsg:  `4:5: templates.contributing.license_path: requires "license_id" to be set`,
+		},
+		{
+			name:     "unknown template",
+			config:   FULL_CONFIG,
+			template: "security",
+			wantMsg:  `unknown template "security", expected one of readme, contributing, pullrequest, bugreport`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := cfg.Path(tt.template); got != tt.wantPath {
+				t.Errorf("Path() = %q, want %q", got, tt.wantPath)
+			}
+
+			doc, err := cfg.Build(tt.template)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Build() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantMsg != "":
+				if err == nil || err.Error() != tt.wantMsg {
+					t.Fatalf("Build() error = %v, want %q", err, tt.wantMsg)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			if doc.Name != tt.wantDoc {
+				t.Errorf("Build().Name = %q, want %q", doc.Name, tt.wantDoc)
+			}
+		})
+	}
+}
+
+func TestDisableDuplicateHeadings(t *testing.T) {
+	cfg, err := Parse([]byte("templates:\n  pullrequest:\n    sections:\n      - name: Related issue\n        markdown: Link the ticket.\n    disable: [Related issue]\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	doc, err := cfg.Build("pullrequest")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if content := render(t, doc); strings.Contains(content, "Related issue") {
+		t.Errorf("every section headed Related issue should be left out:\n%s", content)
+	}
+}
+
+func TestContributingSettings(t *testing.T) {
+	cfg, err := Parse([]byte(`templates:
+  contributing:
+    project_url: https://github.com/acme/widget
+    cla:
+      url: https://cla.acme.example/widget
+    branching: typed
+    commits: conventional
+    merge: squash
+    review:
+      required_approvals: 2
+      code_owners: true
+    release:
+      versioning: Calendar Versioning
+    task_labels: [good first issue]
+    license_id: MIT
+    license_path: ./LICENSE.md
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	doc, err := cfg.Build("contributing")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	content := render(t, doc)
+	for _, want := range []string{
+		"https://cla.acme.example/widget",
+		"fix/123-handle-empty-input",
+		"Conventional Commits",
+		"squash merged",
+		"Pull requests need 2 approving reviews",
+		"Calendar Versioning",
+		"label%3A%22good+first+issue%22",
+		"[MIT License.](./LICENSE.md)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "https://semver.org") {
+		t.Errorf("a custom versioning scheme should not link the default specification:\n%s", content)
+	}
+}
+
+func TestBuildMatchesOptions(t *testing.T) {
+	cfg, err := Parse([]byte(`templates:
+  contributing:
+    project_url: https://github.com/acme/widget
+    table_of_contents: 2
+    sections:
+      - name: Security
+        markdown: Email security@acme.example.
+    disable: [Writing documentation]
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := cfg.Build("contributing")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want, err := contributing.New("https://github.com/acme/widget", "",
+		contributing.WithName(contributing.DefaultName()),
+		contributing.WithTableOfContents(2),
+		contributing.WithSections(
+			sections.Append(Section{Name: "Security", Markdown: "Email security@acme.example."}.Build()),
+			sections.RemoveAll("Writing documentation"),
+		),
+	)
+	if err != nil {
+		t.Fatalf("contributing.New() error = %v", err)
+	}
+
+	if render(t, got) != render(t, want) {
+		t.Errorf("Build() = %s\nwant the document built with the equivalent options:\n%s", render(t, got), render(t, want))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		DEFAULT_PATH:   {Data: []byte("templates:\n  pullrequest: {}\n")},
+		"broken.yaml":  {Data: []byte("templates:\n  pullrequest:\n    nmae: widget\n")},
+		"invalid.yaml": {Data: []byte("templates:\n  pullrequest:\n    disable: [Screenshots]\n")},
+	}
+
+	cfg, err := Load(fsys, DEFAULT_PATH)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Templates.PullRequest == nil || cfg.Templates.Readme != nil {
+		t.Errorf("Load() templates = %+v, want only the pull request", cfg.Templates)
+	}
+
+	_, err = Load(fsys, "broken.yaml")
+	if err == nil || !strings.HasPrefix(err.Error(), "broken.yaml:3:5: templates.pullrequest.nmae: unknown field") {
+		t.Errorf("Load() error = %v", err)
+	}
+
+	cfg, err = Load(fsys, "invalid.yaml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.File() != "invalid.yaml" {
+		t.Errorf("File() = %q, want invalid.yaml", cfg.File())
+	}
+	_, err = cfg.Build("pullrequest")
+	if err == nil || !strings.HasPrefix(err.Error(), `3:15: templates.pullrequest.disable[0]: unknown section "Screenshots"`) {
+		t.Errorf("Build() error = %v", err)
+	}
+
+	_, err = Load(fsys, "missing.yaml")
+	if err == nil || !strings.Contains(err.Error(), "missing.yaml") {
+		t.Errorf("Load() error = %v", err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	cfg, err := Parse([]byte(FULL_CONFIG))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	encoded, err := cfg.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	if !strings.HasPrefix(string(encoded), "# yaml-language-server: $schema="+SCHEMA_ID+"\n") {
+		t.Errorf("Encode() should start with the schema modeline:\n%s", encoded)
+	}
+	if strings.Contains(string(encoded), "table_of_contents: 0") || strings.Contains(string(encoded), `path: ""`) {
+		t.Errorf("Encode() should leave out unset fields:\n%s", encoded)
+	}
+
+	decoded, err := Parse(encoded)
+	if err != nil {
+		t.Fatalf("Parse() of the encoded config error = %v\n%s", err, encoded)
+	}
+
+	for _, name := range TemplateNames() {
+		want, err := cfg.Build(name)
+		if err != nil {
+			t.Fatalf("Build(%q) error = %v", name, err)
+		}
+		got, err := decoded.Build(name)
+		if err != nil {
+			t.Fatalf("Build(%q) of the encoded config error = %v", name, err)
+		}
+
+		if decoded.Path(name) != cfg.Path(name) || render(t, got) != render(t, want) {
+			t.Errorf("%s changed after encoding and parsing the config", name)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+// SCHEMA_PATH is where the JSON Schema for config files is checked in, relative to the repository root.
+const SCHEMA_PATH = "pkg/config/doyoucompute.schema.json"
+
+// SCHEMA_ID is the URL editors load the checked-in schema from.
+const SCHEMA_ID = "https://raw.githubusercontent.com/MoonMoon1919/doyoucompute-templates/main/" + SCHEMA_PATH
+
+// The config types are documented once, in their source, and the schema descriptions are read from there
+//
+//go:embed config.go
+var source string
+
+// Schema returns the JSON Schema describing config files, generated from the Config type.
+// Properties, required fields and enum values come from the same struct tags and Values methods
+// Parse validates against, and descriptions from the types' doc comments, so the schema cannot
+// drift from the code.
+//
+// Point an editor at it with a modeline at the top of the config file:
+//
+//	# yaml-language-server: $schema=https://raw.githubusercontent.com/MoonMoon1919/doyoucompute-templates/main/pkg/config/doyoucompute.schema.json
+func Schema() ([]byte, error) {
+	docs, err := docComments(source)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := schemaFor(reflect.TypeOf(Config{}), docs)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SCHEMA_ID
+	schema["title"] = "doyoucompute-templates config"
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not encode schema: %w", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// schemaFor returns the schema of a config type.
+func schemaFor(t reflect.Type, docs map[string]string) map[string]interface{} {
+	if t.Kind() == reflect.Pointer {
+		// Omitted and null values are both left unset
+		schema := schemaFor(t.Elem(), docs)
+		schema["type"] = []string{schema["type"].(string), "null"}
+
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+
+		for _, field := range fieldsOf(t) {
+			structField := t.FieldByIndex(field.index)
+
+			property := schemaFor(structField.Type, docs)
+			if doc, ok := docs[ownerOf(t, field.index).Name()+"."+structField.Name]; ok {
+				property["description"] = doc
+			}
+
+			properties[field.key] = property
+			if field.required {
+				required = append(required, field.key)
+			}
+		}
+
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		if doc, ok := docs[t.Name()]; ok {
+			schema["description"] = doc
+		}
+
+		return schema
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem(), docs)}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int:
+		return map[string]interface{}{"type": "integer"}
+	}
+
+	schema := map[string]interface{}{"type": "string"}
+	if t.Implements(enumType) {
+		schema["enum"] = reflect.Zero(t).Interface().(enum).Values()
+	}
+
+	return schema
+}
+
+// ownerOf returns the struct that declares the field at index, following inlined structs.
+func ownerOf(t reflect.Type, index []int) reflect.Type {
+	for _, idx := range index[:len(index)-1] {
+		t = t.Field(idx).Type
+	}
+
+	return t
+}
+
+// docComments returns the doc comments of the types declared in src, keyed by type name,
+// and of their fields, keyed by "Type.Field". Comment lines are joined into one sentence run.
+func docComments(src string) (map[string]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "config.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config types: %w", err)
+	}
+
+	docs := map[string]string{}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+
+			if gen.Doc != nil {
+				docs[typeSpec.Name.Name] = commentText(gen.Doc)
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			for _, field := range structType.Fields.List {
+				if field.Doc == nil {
+					continue
+				}
+
+				for _, name := range field.Names {
+					docs[typeSpec.Name.Name+"."+name.Name] = commentText(field.Doc)
+				}
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+func commentText(group *ast.CommentGroup) string {
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
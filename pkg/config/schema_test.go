@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSchemaIsUpToDate(t *testing.T) {
+	want, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path.Base(SCHEMA_PATH))
+	if err != nil {
+		t.Fatalf("could not read the checked-in schema: %v", err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("%s does not match the Config type, run 'make schema' to update it", SCHEMA_PATH)
+	}
+}
+
+// walkSchema calls fn with the path and schema of every property, depth first.
+func walkSchema(schema map[string]interface{}, prefix string, fn func(path string, property map[string]interface{})) {
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		walkSchema(items, prefix+"[]", fn)
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for key, value := range properties {
+		property := value.(map[string]interface{})
+		propertyPath := join(prefix, key)
+
+		fn(propertyPath, property)
+		walkSchema(property, propertyPath, fn)
+	}
+}
+
+func asStrings(value interface{}) []string {
+	values, _ := value.([]interface{})
+
+	converted := make([]string, len(values))
+	for idx, value := range values {
+		converted[idx] = value.(string)
+	}
+
+	return converted
+}
+
+func TestSchemaMatchesValidation(t *testing.T) {
+	content, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("Schema() is not valid JSON: %v", err)
+	}
+
+	properties := map[string]map[string]interface{}{}
+	walkSchema(schema, "", func(path string, property map[string]interface{}) {
+		properties[path] = property
+
+		if property["description"] == nil {
+			t.Errorf("%s has no description, document the field in config.go", path)
+		}
+	})
+
+	tests := []struct {
+		path     string
+		required []string
+		enum     []string
+		keys     []string
+	}{
+		{path: "templates.readme", required: []string{"features", "quickstart"}, keys: []string{"contributing", "disable", "features", "intro", "license", "name", "path", "quickstart", "sections", "skip", "table_of_contents"}},
+		{path: "templates.readme.sections[]", required: []string{"name", "markdown"}},
+		{path: "templates.contributing", required: []string{"project_url"}, keys: []string{"branching", "cla", "commits", "dco", "default_branch", "disable", "issue_tracker_url", "license_id", "license_path", "merge", "name", "path", "preset", "profile", "project_url", "release", "review", "sections", "sign_off", "skip", "table_of_contents", "task_labels"}},
+		{path: "templates.contributing.preset", enum: Preset("").Values()},
+		{path: "templates.contributing.profile", required: []string{"name", "test"}},
+		{path: "templates.contributing.cla", required: []string{"url"}},
+		{path: "templates.contributing.branching", enum: Branching("").Values()},
+		{path: "templates.contributing.commits", enum: Commits("").Values()},
+		{path: "templates.contributing.merge", enum: Merge("").Values()},
+		{path: "templates.contributing.task_labels[]", enum: Label("").Values()},
+		{path: "templates.pullrequest", keys: []string{"disable", "name", "path", "sections", "skip"}},
+		{path: "templates.bugreport", keys: []string{"about", "assignees", "disable", "labels", "name", "path", "sections", "skip", "title"}},
+		{path: "templates.bugreport.labels[]", enum: Label("").Values()},
+		{path: "derived[]", required: []string{"template", "base"}, keys: []string{"base", "description", "disable", "frontmatter", "name", "path", "sections", "skip", "template"}},
+		{path: "derived[].frontmatter[]", required: []string{"key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			parent, key := tt.path, ""
+			if strings.HasSuffix(tt.path, "[]") {
+				parent, key = strings.TrimSuffix(tt.path, "[]"), "items"
+			}
+
+			property, ok := properties[parent]
+			if !ok {
+				t.Fatalf("schema has no property %s", parent)
+			}
+			if key != "" {
+				property = property[key].(map[string]interface{})
+			}
+
+			if tt.required != nil && !reflect.DeepEqual(asStrings(property["required"]), tt.required) {
+				t.Errorf("required = %v, want %v", property["required"], tt.required)
+			}
+			if tt.enum != nil && !reflect.DeepEqual(asStrings(property["enum"]), tt.enum) {
+				t.Errorf("enum = %v, want %v", property["enum"], tt.enum)
+			}
+			if tt.keys != nil {
+				var keys []string
+				for key := range property["properties"].(map[string]interface{}) {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+
+				if !reflect.DeepEqual(keys, tt.keys) {
+					t.Errorf("properties = %v, want %v", keys, tt.keys)
+				}
+			}
+		})
+	}
+}
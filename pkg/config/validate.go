@@ -0,0 +1,276 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a location in a config file.
+type Position struct {
+	// Line is the 1-based line number
+	Line int
+	// Column is the 1-based column number
+	Column int
+}
+
+// FieldError is a problem with a value in a config file.
+type FieldError struct {
+	Position
+	// Path is the dotted path to the value, e.g. "templates.readme.features"
+	Path string
+	// Message describes the problem
+	Message string
+}
+
+// Error returns the error as "line:column: path: message".
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// ValidationError lists every problem found in a config file.
+type ValidationError struct {
+	// File is the name of the config file, empty when it was parsed from bytes
+	File string
+	// Errors are the problems in the order they appear in the file
+	Errors []FieldError
+}
+
+// Error returns one line per problem, prefixed with the file name when there is one.
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+
+	for idx, err := range e.Errors {
+		if e.File != "" {
+			lines[idx] = e.File + ":" + err.Error()
+		} else {
+			lines[idx] = err.Error()
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// enum is implemented by string types that only accept a fixed set of values.
+type enum interface {
+	Values() []string
+}
+
+var enumType = reflect.TypeOf((*enum)(nil)).Elem()
+
+// field is a struct field as it appears in a config file.
+type field struct {
+	key      string
+	index    []int
+	required bool
+}
+
+// fieldsOf returns the config fields of a struct type, including those of inlined structs.
+func fieldsOf(t reflect.Type) []field {
+	var fields []field
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		structField := t.Field(idx)
+		if !structField.IsExported() {
+			continue
+		}
+
+		key, options, _ := strings.Cut(structField.Tag.Get("yaml"), ",")
+
+		if options == "inline" {
+			for _, inlined := range fieldsOf(structField.Type) {
+				inlined.index = append([]int{idx}, inlined.index...)
+				fields = append(fields, inlined)
+			}
+			continue
+		}
+
+		fields = append(fields, field{
+			key:      key,
+			index:    []int{idx},
+			required: structField.Tag.Get("config") == "required",
+		})
+	}
+
+	return fields
+}
+
+// checker compares a parsed YAML document with the shape of the config structs,
+// recording where every value appears so later errors can point at it.
+type checker struct {
+	positions map[string]Position
+	errors    []FieldError
+}
+
+func newChecker() *checker {
+	return &checker{positions: map[string]Position{}}
+}
+
+func (c *checker) fail(node *yaml.Node, path, format string, args ...interface{}) {
+	c.errors = append(c.errors, FieldError{
+		Position: Position{Line: node.Line, Column: node.Column},
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) check(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if t.Kind() == reflect.Pointer {
+		if isNull(node) {
+			return
+		}
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		c.checkStruct(node, t, path)
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			c.fail(node, path, "expected a list")
+			return
+		}
+
+		for idx, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, idx)
+			c.positions[itemPath] = Position{Line: item.Line, Column: item.Column}
+			c.check(item, t.Elem(), itemPath)
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			c.fail(node, path, "expected a string")
+			return
+		}
+
+		if t.Implements(enumType) && !isNull(node) {
+			values := reflect.Zero(t).Interface().(enum).Values()
+			if !contains(values, node.Value) {
+				c.fail(node, path, "%q is not one of %s", node.Value, strings.Join(values, ", "))
+			}
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			c.fail(node, path, "expected true or false")
+		}
+	case reflect.Int:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			c.fail(node, path, "expected an integer")
+		}
+	}
+}
+
+func (c *checker) checkStruct(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind != yaml.MappingNode {
+		c.fail(node, path, "expected a mapping")
+		return
+	}
+
+	fields := fieldsOf(t)
+	seen := map[string]*yaml.Node{}
+
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		key, value := node.Content[idx], node.Content[idx+1]
+		keyPath := join(path, key.Value)
+
+		if previous, ok := seen[key.Value]; ok {
+			c.fail(key, keyPath, "already set on line %d", previous.Line)
+			continue
+		}
+		seen[key.Value] = key
+
+		field, ok := lookup(fields, key.Value)
+		if !ok {
+			c.fail(key, keyPath, "unknown field, expected one of %s", strings.Join(keys(fields), ", "))
+			continue
+		}
+
+		c.positions[keyPath] = Position{Line: key.Line, Column: key.Column}
+		c.check(value, t.FieldByIndex(field.index).Type, keyPath)
+	}
+
+	// Missing fields are reported where the mapping's own key is, rather than at its first field
+	position := c.positions[path]
+	for _, field := range fields {
+		if _, ok := seen[field.key]; field.required && !ok {
+			c.errors = append(c.errors, FieldError{Position: position, Path: path, Message: fmt.Sprintf("missing required field %q", field.key)})
+		}
+	}
+}
+
+// sorted returns the errors in the order they appear in the file.
+func (c *checker) sorted() []FieldError {
+	sort.SliceStable(c.errors, func(i, j int) bool {
+		a, b := c.errors[i].Position, c.errors[j].Position
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+
+	return c.errors
+}
+
+// position returns where path, or its closest parent, appears in the file.
+func (c *checker) position(path string) Position {
+	for {
+		if position, ok := c.positions[path]; ok {
+			return position
+		}
+
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			return c.positions[""]
+		}
+
+		path = path[:cut]
+	}
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func lookup(fields []field, key string) (field, bool) {
+	for _, field := range fields {
+		if field.key == key {
+			return field, true
+		}
+	}
+
+	return field{}, false
+}
+
+func keys(fields []field) []string {
+	names := make([]string, len(fields))
+	for idx, field := range fields {
+		names[idx] = field.key
+	}
+
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
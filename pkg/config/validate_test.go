@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "empty file",
+			config: "",
+			want:   []string{"1:1: config is empty"},
+		},
+		{
+			name:   "missing templates",
+			config: "template:\n  readme: {}\n",
+			want: []string{
+				"1:1: template: unknown field, expected one of templates, derived",
+				`1:1: missing required field "templates"`,
+			},
+		},
+		{
+			name:   "unknown field",
+			config: "templates:\n  pullrequest:\n    title: Change\n",
+			want:   []string{"3:5: templates.pullrequest.title: unknown field, expected one of path, name, disable, sections, skip"},
+		},
+		{
+			name:   "wrong types",
+			config: "templates:\n  readme:\n    name: [widget]\n    features: x\n    quickstart: y\n    table_of_contents: two\n    disable: Features\n",
+			want: []string{
+				"3:11: templates.readme.name: expected a string",
+				"6:24: templates.readme.table_of_contents: expected an integer",
+				"7:14: templates.readme.disable: expected a list",
+			},
+		},
+		{
+			name:   "missing required fields",
+			config: "templates:\n  readme:\n    name: widget\n  bugreport:\n    sections:\n      - name: Screenshots\n",
+			want: []string{
+				`2:3: templates.readme: missing required field "features"`,
+				`2:3: templates.readme: missing required field "quickstart"`,
+				`6:9: templates.bugreport.sections[0]: missing required field "markdown"`,
+			},
+		},
+		{
+			name:   "enum values",
+			config: "templates:\n  contributing:\n    project_url: https://github.com/acme/widget\n    preset: closed-source\n    sign_off: yes please\n  bugreport:\n    labels: [bug, urgent]\n",
+			want: []string{
+				`4:13: templates.contributing.preset: "closed-source" is not one of open-source, internal`,
+				"5:15: templates.contributing.sign_off: expected true or false",
+				`7:19: templates.bugreport.labels[1]: "urgent" is not one of bug, documentation, enhancement, good first issue, help wanted`,
+			},
+		},
+		{
+			name:   "contributing convention names",
+			config: "templates:\n  contributing:\n    project_url: https://github.com/acme/widget\n    merge: fast-forward\n    review:\n      required_approvals: two\n",
+			want: []string{
+				`4:12: templates.contributing.merge: "fast-forward" is not one of squash, merge, rebase`,
+				"6:27: templates.contributing.review.required_approvals: expected an integer",
+			},
+		},
+		{
+			name:   "duplicate field",
+			config: "templates:\n  pullrequest:\n    name: One\n    name: Two\n",
+			want:   []string{"4:5: templates.pullrequest.name: already set on line 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config))
+
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Parse() error = %v, want a *ValidationError", err)
+			}
+
+			got := make([]string, len(invalid.Errors))
+			for idx, fieldErr := range invalid.Errors {
+				got[idx] = fieldErr.Error()
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Parse() errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	_, err := Parse([]byte("templates:\n  readme: [\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "could not parse config: yaml: line") {
+		t.Errorf("Parse() error = %v", err)
+	}
+}
+
+func TestValidationErrorNamesFile(t *testing.T) {
+	err := &ValidationError{
+		File: ".doyoucompute.yaml",
+		Errors: []FieldError{
+			{Position: Position{Line: 3, Column: 5}, Path: "templates.readme.nmae", Message: "unknown field"},
+			{Position: Position{Line: 1, Column: 1}, Message: "config is empty"},
+		},
+	}
+
+	want := ".doyoucompute.yaml:3:5: templates.readme.nmae: unknown field\n.doyoucompute.yaml:1:1: config is empty"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -14,6 +14,54 @@
 //		// handle error
 //	}
 //
+// The issue tracker URL is optional for GitHub, GitLab and Bitbucket projects;
+// pass an empty string to derive it from the project URL:
+//
+//	doc, err := contributing.New("git@gitlab.com:group/subgroup/project.git", "")
+//
+// From detected project metadata:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	doc, err := contributing.NewFromProject(info)
+//
+// Other language ecosystems are supported through profiles:
+//
+//	doc, err := contributing.New(
+//		"https://github.com/username/project",
+//		"",
+//		contributing.WithProfile(contributing.NodePnpmProfile()),
+//	)
+//
+// Projects that require a Developer Certificate of Origin sign-off or a
+// Contributor License Agreement get a legal section:
+//
+//	doc, err := contributing.New(
+//		"https://github.com/username/project",
+//		"",
+//		contributing.WithDCO(),
+//		contributing.WithLicenseID("Apache-2.0", ""),
+//	)
+//
+// Internal repositories without forks use the trunk-based preset:
+//
+//	doc, err := contributing.New(
+//		"https://github.com/company/service",
+//		"",
+//		contributing.WithPreset(contributing.InternalPreset),
+//	)
+//
+// Review and release process sections are optional:
+//
+//	doc, err := contributing.New(
+//		"https://github.com/username/project",
+//		"",
+//		contributing.WithReviewProcess(contributing.DefaultReviewProcess()),
+//		contributing.WithReleaseProcess(contributing.DefaultReleaseProcess()),
+//	)
+//
 // Customizing sections:
 //
 //	doc, err := contributing.New(
@@ -22,6 +70,15 @@
 //		contributing.WithName("Contributing Guidelines"),
 //		contributing.WithSetup(customSetupSection),
 //	)
+//
+// Writing your own option, which runs alongside the built-in ones in the order given:
+//
+//	func WithSupportChannel(url string) contributing.Option {
+//		return func(p *contributing.ContributingProps) (doyoucompute.Finalizer[contributing.ContributingProps], error) {
+//			p.GettingStarted.WriteParagraph().Text("Questions are welcome in").Link("the forum", url)
+//			return nil, nil
+//		}
+//	}
 package contributing
 
 import (
@@ -29,22 +86,81 @@ import (
 	"strings"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/toc"
 )
 
-type contributingProps struct {
-	name            string
-	projectUrl      string
-	issueTrackerUrl string
-	gettingStarted  doyoucompute.Section
-	choseATask      doyoucompute.Section
-	setup           doyoucompute.Section
-	development     doyoucompute.Section
-	submissions     doyoucompute.Section
-	writingDocs     doyoucompute.Section
-	reportingbugs   doyoucompute.Section
-	license         doyoucompute.Section
+// DEFAULT_PATH is the conventional location of the contribution guidelines, at the repository root.
+const DEFAULT_PATH = "CONTRIBUTING.md"
+
+// ContributingProps holds everything New renders. Defaults returns the values New starts
+// from, and each option then changes them in the order given, so options outside this
+// package can be written as any doyoucompute.OptionBuilder[ContributingProps].
+//
+// Several sections are built from other fields, and the built-in options that change those
+// fields rebuild the sections depending on them. A custom option changing Profile,
+// Conventions, Tasks or IssueTrackerUrl should hand the new value to the matching built-in
+// option, e.g. return WithProfile(profile)(p), so the sections stay in step.
+type ContributingProps struct {
+	// Document name
+	Name string
+	// Web url of the project
+	ProjectUrl string
+	// Repository parsed from the project url
+	Repository repourl.URL
+	// Issue tracker url, derived from the repository when not given
+	IssueTrackerUrl string
+	// Labels linked as issue searches in the find a task section
+	TaskLabels []labels.Label
+	// Contribution workflow the setup, development and submission sections describe
+	Preset Preset
+	// Branch changes are merged into, used in the sign-off fix-up command
+	DefaultBranch string
+	// Language ecosystem commands used in the setup and development sections
+	Profile Profile
+	// Makefile targets listed in the development section
+	Tasks []makefile.Target
+	// Branch, commit and merge conventions listed in the development section
+	Conventions Conventions
+	// Getting started section
+	GettingStarted doyoucompute.Section
+	// Find a task section, nested under getting started
+	ChoseATask doyoucompute.Section
+	// Development environment setup section
+	Setup doyoucompute.Section
+	// Development workflow section
+	Development doyoucompute.Section
+	// Submitting changes section
+	Submissions doyoucompute.Section
+	// Writing documentation section
+	WritingDocs doyoucompute.Section
+	// Reporting bugs section
+	ReportingBugs doyoucompute.Section
+	// Review process section, left out when it has no name
+	Review doyoucompute.Section
+	// Release process section, left out when it has no name
+	Release doyoucompute.Section
+	// Whether contributors must sign off under the Developer Certificate of Origin
+	DCO bool
+	// Contributor License Agreement contributors must sign, if any
+	CLA CLA
+	// Legal section, left out when it has no name
+	Legal doyoucompute.Section
+	// License section
+	License doyoucompute.Section
+	// Depth of the table of contents, or 0 for none
+	TableOfContents int
+	// Section edits applied once the sections above are in place
+	Edits []sections.Edit
 }
 
+// Option changes the props of a contributing guide before it is rendered.
+type Option = doyoucompute.OptionBuilder[ContributingProps]
+
 // DefaultName returns the default document name.
 func DefaultName() string {
 	return "Contributing"
@@ -55,45 +171,226 @@ func DefaultName() string {
 // Example:
 //
 //	contributing.WithName("Contributing Guidelines")
-func WithName(name string) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.name = name
+func WithName(name string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Name = name
 
 		return nil, nil
 	}
 }
 
-// WithProjectUrl overrides the project URL.
+// WithTableOfContents inserts a table of contents before the first section,
+// listing sections down to depth, where 1 lists only top-level sections.
 //
 // Example:
 //
-//	contributing.WithProjectUrl("https://github.com/username/project")
-func WithProjectUrl(url string) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.projectUrl = url
+//	contributing.WithTableOfContents(3)
+func WithTableOfContents(depth int) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if depth < 1 {
+			return nil, fmt.Errorf("table of contents depth must be at least 1, got %d", depth)
+		}
+
+		p.TableOfContents = depth
 
 		return nil, nil
 	}
 }
 
+// WithProjectUrl overrides the project URL and updates dependent sections.
+//
+// Example:
+//
+//	contributing.WithProjectUrl("https://github.com/username/project")
+func WithProjectUrl(url string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		repository, err := repourl.ParseProject(url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid projectUrl: %w", err)
+		}
+
+		p.ProjectUrl = url
+		p.Repository = repository
+
+		return func(p *ContributingProps) error {
+			p.Setup = p.presetSetup()
+
+			return nil
+		}, nil
+	}
+}
+
+// WithProfile sets the language profile and updates the setup and development sections.
+// Use one of the built-in profiles, DetectProfile, or a custom Profile.
+//
+// Example:
+//
+//	contributing.WithProfile(contributing.RustProfile())
+func WithProfile(profile Profile) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if err := profile.Valid(); err != nil {
+			return nil, err
+		}
+
+		p.Profile = profile
+
+		return func(p *ContributingProps) error {
+			p.Setup = p.presetSetup()
+			p.Development = p.presetDevelopment()
+
+			return nil
+		}, nil
+	}
+}
+
+// WithMakefileTasks lists Makefile targets in a "Common tasks" section of the development workflow,
+// in place of the profile's test and lint commands.
+//
+// Example:
+//
+//	targets, err := makefile.ParseFile(os.DirFS("."), "Makefile")
+//	if err != nil {
+//		// handle error
+//	}
+//	contributing.WithMakefileTasks(makefile.Documented(targets))
+func WithMakefileTasks(tasks []makefile.Target) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if len(tasks) == 0 {
+			return nil, fmt.Errorf("makefile tasks cannot be empty")
+		}
+
+		p.Tasks = tasks
+
+		return func(p *ContributingProps) error {
+			p.Development = p.presetDevelopment()
+
+			return nil
+		}, nil
+	}
+}
+
 // WithIssueTrackerUrl overrides the issue tracker URL and updates dependent sections.
+// Labels set with WithTaskLabels are linked on the new tracker, so it fails when the
+// tracker's forge does not support searching issues by label.
 //
 // Example:
 //
 //	contributing.WithIssueTrackerUrl("https://github.com/username/project/issues")
-func WithIssueTrackerUrl(url string) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.issueTrackerUrl = url
+func WithIssueTrackerUrl(url string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.IssueTrackerUrl = url
 
-		return func(p *contributingProps) error {
-			p.choseATask = DefaultChoseATask(url)
-			p.reportingbugs = DefaultReportingBugs(url)
+		return func(p *ContributingProps) error {
+			taskLinks, err := issueTrackerTaskLinks(url, p.TaskLabels)
+			if err != nil {
+				return fmt.Errorf("could not link task labels: %w", err)
+			}
+
+			p.ChoseATask = DefaultChoseATask(url, taskLinks...)
+			p.ReportingBugs = DefaultReportingBugs(url)
 
 			return nil
 		}, nil
 	}
 }
 
+// WithDefaultBranch sets the branch changes are merged into, named by the internal preset's
+// development and submissions sections and by the legal section when contributors must sign
+// off their commits. Defaults to main.
+//
+// Example:
+//
+//	contributing.WithDefaultBranch("trunk")
+func WithDefaultBranch(branch string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if branch == "" {
+			return nil, fmt.Errorf("default branch cannot be empty")
+		}
+
+		p.DefaultBranch = branch
+
+		return func(p *ContributingProps) error {
+			if p.DCO {
+				p.Legal = p.presetLegal()
+			}
+
+			return rebuildConventionSections(p)
+		}, nil
+	}
+}
+
+// WithBranchScheme sets the branch naming scheme and updates the development and submissions sections.
+//
+// Example:
+//
+//	contributing.WithBranchScheme(contributing.TypedBranchScheme())
+func WithBranchScheme(scheme BranchScheme) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if scheme.Example == "" {
+			return nil, fmt.Errorf("branch scheme example cannot be empty")
+		}
+
+		p.Conventions.Branching = scheme
+
+		return rebuildConventionSections, nil
+	}
+}
+
+// WithCommitConvention sets the commit message convention and updates the submissions section.
+//
+// Example:
+//
+//	contributing.WithCommitConvention(contributing.ConventionalCommits())
+func WithCommitConvention(convention CommitConvention) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if err := convention.Valid(); err != nil {
+			return nil, err
+		}
+
+		p.Conventions.Commits = convention
+
+		return rebuildConventionSections, nil
+	}
+}
+
+// WithSignOff requires commits to be signed off and updates the submissions section.
+//
+// Example:
+//
+//	contributing.WithSignOff()
+func WithSignOff() doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Conventions.SignOff = true
+
+		return rebuildConventionSections, nil
+	}
+}
+
+// WithMergePolicy sets how pull requests are merged and updates the submissions section.
+//
+// Example:
+//
+//	contributing.WithMergePolicy(contributing.SquashMerge)
+func WithMergePolicy(policy MergePolicy) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if policy < UnspecifiedMerge || policy > RebaseMerge {
+			return nil, fmt.Errorf("unknown merge policy: %d", policy)
+		}
+
+		p.Conventions.Merge = policy
+
+		return rebuildConventionSections, nil
+	}
+}
+
+// rebuildConventionSections regenerates the sections that render the conventions.
+func rebuildConventionSections(p *ContributingProps) error {
+	p.Development = p.presetDevelopment()
+	p.Submissions = p.presetSubmissions()
+
+	return nil
+}
+
 // WithGettingStarted overrides the getting started section.
 // This replaces the entire section, including the title.
 //
@@ -102,9 +399,9 @@ func WithIssueTrackerUrl(url string) doyoucompute.OptionBuilder[contributingProp
 //	section := doyoucompute.NewSection("Getting started")
 //	section.WriteParagraph().Text("Read our documentation first")
 //	contributing.WithGettingStarted(section)
-func WithGettingStarted(gettingStarted doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.gettingStarted = gettingStarted
+func WithGettingStarted(gettingStarted doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.GettingStarted = gettingStarted
 
 		return nil, nil
 	}
@@ -118,9 +415,9 @@ func WithGettingStarted(gettingStarted doyoucompute.Section) doyoucompute.Option
 //	section := doyoucompute.NewSection("Find a task")
 //	section.WriteParagraph().Text("Check our project board")
 //	contributing.WithChoseATask(section)
-func WithChoseATask(task doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.choseATask = task
+func WithChoseATask(task doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.ChoseATask = task
 
 		return nil, nil
 	}
@@ -134,9 +431,9 @@ func WithChoseATask(task doyoucompute.Section) doyoucompute.OptionBuilder[contri
 //	section := doyoucompute.NewSection("Setup")
 //	section.WriteParagraph().Text("Install Docker first")
 //	contributing.WithSetup(section)
-func WithSetup(setup doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.setup = setup
+func WithSetup(setup doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Setup = setup
 
 		return nil, nil
 	}
@@ -150,9 +447,9 @@ func WithSetup(setup doyoucompute.Section) doyoucompute.OptionBuilder[contributi
 //	section := doyoucompute.NewSection("Development")
 //	section.WriteParagraph().Text("Use our pre-commit hooks")
 //	contributing.WithDevelopment(section)
-func WithDevelopment(development doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.development = development
+func WithDevelopment(development doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Development = development
 
 		return nil, nil
 	}
@@ -166,9 +463,9 @@ func WithDevelopment(development doyoucompute.Section) doyoucompute.OptionBuilde
 //	section := doyoucompute.NewSection("Submitting PRs")
 //	section.WriteParagraph().Text("Ensure CI passes before requesting review")
 //	contributing.WithSubmissions(section)
-func WithSubmissions(submissions doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.submissions = submissions
+func WithSubmissions(submissions doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Submissions = submissions
 
 		return nil, nil
 	}
@@ -182,9 +479,9 @@ func WithSubmissions(submissions doyoucompute.Section) doyoucompute.OptionBuilde
 //	section := doyoucompute.NewSection("Documentation")
 //	section.WriteParagraph().Text("We use MkDocs for documentation")
 //	contributing.WithWritingDocs(section)
-func WithWritingDocs(docs doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.writingDocs = docs
+func WithWritingDocs(docs doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.WritingDocs = docs
 
 		return nil, nil
 	}
@@ -198,9 +495,9 @@ func WithWritingDocs(docs doyoucompute.Section) doyoucompute.OptionBuilder[contr
 //	section := doyoucompute.NewSection("Bug Reports")
 //	section.WriteParagraph().Text("Include system information")
 //	contributing.WithReportingbugs(section)
-func WithReportingbugs(bugs doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.reportingbugs = bugs
+func WithReportingbugs(bugs doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.ReportingBugs = bugs
 
 		return nil, nil
 	}
@@ -214,9 +511,26 @@ func WithReportingbugs(bugs doyoucompute.Section) doyoucompute.OptionBuilder[con
 //	section := doyoucompute.NewSection("License")
 //	section.WriteParagraph().Text("MIT License applies")
 //	contributing.WithLicense(section)
-func WithLicense(license doyoucompute.Section) doyoucompute.OptionBuilder[contributingProps] {
-	return func(p *contributingProps) (doyoucompute.Finalizer[contributingProps], error) {
-		p.license = license
+func WithLicense(license doyoucompute.Section) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.License = license
+
+		return nil, nil
+	}
+}
+
+// WithSections adds, moves or removes sections once the default sections are in place.
+// Edits naming a section the guide does not have fail with sections.ErrUnknownSection.
+//
+// Example:
+//
+//	contributing.WithSections(
+//		sections.InsertAfter("Reporting bugs", securitySection),
+//		sections.Remove("Writing documentation"),
+//	)
+func WithSections(edits ...sections.Edit) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.Edits = append(p.Edits, edits...)
 
 		return nil, nil
 	}
@@ -237,13 +551,29 @@ func DefaultGettingStarted() doyoucompute.Section {
 }
 
 // DefaultChoseATask returns the default task selection section.
-func DefaultChoseATask(issueTrackerUrl string) doyoucompute.Section {
+// Each link is listed as a filtered issue search, e.g. issues labelled "good first issue".
+func DefaultChoseATask(issueTrackerUrl string, links ...TaskLink) doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Find a task", func(s *doyoucompute.Section) error {
 		s.WriteParagraph().
 			Text("Browse the").
 			Link("issue tracker", issueTrackerUrl).
 			Text(" to see what's being worked on and what needs attention.")
 
+		if len(links) > 0 {
+			s.WriteParagraph().
+				Text("New to the project? These searches list issues that are a good place to start:")
+
+			labelList := s.CreateList(doyoucompute.BULLET)
+			for _, link := range links {
+				item := fmt.Sprintf("[%s](%s)", link.Label.Name, link.Url)
+				if link.Label.Description != "" {
+					item = fmt.Sprintf("%s - %s", item, link.Label.Description)
+				}
+
+				labelList.Append(item)
+			}
+		}
+
 		s.WriteParagraph().
 			Text("Don't see anything that interests you? Feel free to open a new issue to:")
 
@@ -313,6 +643,11 @@ func DefaultReportingBugs(issueTrackerUrl string) doyoucompute.Section {
 
 // DefaultOpenSourceGoSetupGuidelines returns the default setup section for Go projects.
 func DefaultOpenSourceGoSetupGuidelines(projectUrl string, projectName string) doyoucompute.Section {
+	return DefaultOpenSourceSetupGuidelines(projectUrl, projectName, GoProfile())
+}
+
+// DefaultOpenSourceSetupGuidelines returns the default setup section using the profile's install and test commands.
+func DefaultOpenSourceSetupGuidelines(projectUrl string, projectName string, profile Profile) doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Setting Up Your Development Environment", func(s *doyoucompute.Section) error {
 		s.WriteParagraph().
 			Text("First, fork the repository on GitHub at").
@@ -328,8 +663,8 @@ func DefaultOpenSourceGoSetupGuidelines(projectUrl string, projectName string) d
 		s.WriteParagraph().
 			Text("Install dependencies and verify you can run the tests:")
 
-		s.WriteCodeBlock("bash", []string{"go mod tidy"}, doyoucompute.Static)
-		s.WriteCodeBlock("bash", []string{"go test ./..."}, doyoucompute.Static)
+		writeCommands(s, profile.Install)
+		writeCommands(s, profile.Test)
 
 		return nil
 	})
@@ -339,19 +674,74 @@ func DefaultOpenSourceGoSetupGuidelines(projectUrl string, projectName string) d
 
 // DefaultOpenSourceGoDevelopmentGuidelines returns the default development workflow section for Go projects.
 func DefaultOpenSourceGoDevelopmentGuidelines() doyoucompute.Section {
+	return DefaultOpenSourceDevelopmentGuidelines(GoProfile(), nil, DefaultConventions())
+}
+
+// DefaultOpenSourceDevelopmentGuidelines returns the default development workflow section.
+// When tasks are provided they are listed in a "Common tasks" section,
+// otherwise the profile's test and lint commands are shown.
+// The branch naming scheme comes from the conventions.
+func DefaultOpenSourceDevelopmentGuidelines(profile Profile, tasks []makefile.Target, conventions Conventions) doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Development Workflow", func(s *doyoucompute.Section) error {
+		writeDevelopmentWorkflow(s, profile, tasks, conventions)
+
+		return nil
+	})
+
+	return section
+}
+
+// writeDevelopmentWorkflow writes the branch, test and lint instructions shared by every preset.
+func writeDevelopmentWorkflow(s *doyoucompute.Section, profile Profile, tasks []makefile.Target, conventions Conventions) {
+	if conventions.Branching.Pattern != "" {
+		s.WriteParagraph().
+			Text("Create a new branch for your feature or bug fix, named").
+			Code(conventions.Branching.Pattern).
+			Text(":")
+	} else {
 		s.WriteParagraph().
 			Text("Create a new branch for your feature or bug fix:")
+	}
 
-		s.WriteCodeBlock("bash", []string{"git checkout -b feature/my-awesome-feature"}, doyoucompute.Static)
+	s.WriteCodeBlock("bash", []string{fmt.Sprintf("git checkout -b %s", conventions.Branching.Example)}, doyoucompute.Static)
 
+	if len(tasks) > 0 {
+		s.WriteParagraph().
+			Text("Make your changes and add tests for new functionality. Use the Makefile targets below to test and check your changes.")
+	} else {
 		s.WriteParagraph().
 			Text("Make your changes and add tests for new functionality. Run tests to ensure changes work as expected:")
 
-		s.WriteCodeBlock("bash", []string{"go test ./..."}, doyoucompute.Static)
+		writeCommands(s, profile.Test)
 
-		s.WriteParagraph().
-			Text("If you're adding new features, consider adding example usage in the examples directory.")
+		if len(profile.Lint) > 0 {
+			s.WriteParagraph().
+				Text("Run the linters before committing:")
+
+			writeCommands(s, profile.Lint)
+		}
+	}
+
+	s.WriteParagraph().
+		Text("If you're adding new features, consider adding example usage in the examples directory.")
+
+	if len(tasks) > 0 {
+		s.AddSection(DefaultCommonTasks(tasks))
+	}
+}
+
+// DefaultCommonTasks returns a section listing Makefile targets and their descriptions.
+func DefaultCommonTasks(tasks []makefile.Target) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Common tasks", func(s *doyoucompute.Section) error {
+		s.WriteIntro().
+			Text("Run any of these tasks from the root of the repository:")
+
+		table := s.CreateTable([]string{"Target", "Description"})
+		for _, task := range tasks {
+			if err := table.AddRow(fmt.Sprintf("`make %s`", task.Name), task.Description); err != nil {
+				return err
+			}
+		}
 
 		return nil
 	})
@@ -359,19 +749,28 @@ func DefaultOpenSourceGoDevelopmentGuidelines() doyoucompute.Section {
 	return section
 }
 
+// writeCommands writes each command as its own bash code block.
+func writeCommands(s *doyoucompute.Section, commands []string) {
+	for _, command := range commands {
+		s.WriteCodeBlock("bash", []string{command}, doyoucompute.Static)
+	}
+}
+
 // DefaultOpenSourceSubmittingGuidelines returns the default submission guidelines section.
 func DefaultOpenSourceSubmittingGuidelines() doyoucompute.Section {
-	section, _ := doyoucompute.SectionFactory("Submitting your changes", func(s *doyoucompute.Section) error {
-		s.WriteParagraph().
-			Text("Once you're satisfied with your changes, commit them with a descriptive message:")
+	return DefaultOpenSourceSubmittingGuidelinesFor(DefaultConventions(), metadata.DEFAULT_BRANCH)
+}
 
-		s.WriteCodeBlock("bash", []string{"git add ."}, doyoucompute.Static)
-		s.WriteCodeBlock("bash", []string{"git commit -m \"Add feature: descriptive commit message\""}, doyoucompute.Static)
+// DefaultOpenSourceSubmittingGuidelinesFor returns the submission guidelines section for the conventions,
+// for pull requests against branch.
+func DefaultOpenSourceSubmittingGuidelinesFor(conventions Conventions, branch string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Submitting your changes", func(s *doyoucompute.Section) error {
+		writeCommitGuidelines(s, conventions)
 
 		s.WriteParagraph().
 			Text("Push your changes to your forked repository:")
 
-		s.WriteCodeBlock("bash", []string{"git push origin feature/my-awesome-feature"}, doyoucompute.Static)
+		s.WriteCodeBlock("bash", []string{fmt.Sprintf("git push origin %s", conventions.Branching.Example)}, doyoucompute.Static)
 
 		s.WriteParagraph().
 			Text("Finally, create a pull request:")
@@ -383,17 +782,116 @@ func DefaultOpenSourceSubmittingGuidelines() doyoucompute.Section {
 		submissionSteps.Append("Reference any relevant issues using #issue-number")
 		submissionSteps.Append("Wait for review and address any feedback")
 
+		if policy := conventions.Merge.Description(branch); policy != "" {
+			submissionSteps.Append(policy)
+		}
+
 		return nil
 	})
 
 	return section
 }
 
+// writeCommitGuidelines writes the commit instructions for the conventions.
+func writeCommitGuidelines(s *doyoucompute.Section, conventions Conventions) {
+	commits := conventions.Commits
+
+	if commits.Name == "" {
+		s.WriteParagraph().
+			Text("Once you're satisfied with your changes, commit them with a descriptive message:")
+	} else {
+		s.WriteParagraph().
+			Text("Once you're satisfied with your changes, commit them following the").
+			Link(commits.Name, commits.Url).
+			Text("convention:")
+	}
+
+	if len(commits.Types) > 0 {
+		types := make([]string, len(commits.Types))
+		for idx, commitType := range commits.Types {
+			types[idx] = fmt.Sprintf("`%s`", commitType)
+		}
+
+		s.WriteParagraph().Text("Allowed types are " + strings.Join(types, ", "))
+	}
+
+	commitFlags := "-m"
+	if conventions.SignOff {
+		commitFlags = "-s -m"
+	}
+
+	s.WriteCodeBlock("bash", []string{"git add ."}, doyoucompute.Static)
+
+	// New rejects conventions without examples, but options run before it checks
+	if len(commits.Examples) > 0 {
+		s.WriteCodeBlock("bash", []string{fmt.Sprintf("git commit %s \"%s\"", commitFlags, commits.Examples[0])}, doyoucompute.Static)
+	}
+
+	if len(commits.Examples) > 1 {
+		s.WriteParagraph().Text("More examples of commit messages:")
+
+		examples := s.CreateList(doyoucompute.BULLET)
+		for _, example := range commits.Examples[1:] {
+			examples.Append(fmt.Sprintf("`%s`", example))
+		}
+	}
+
+	if conventions.SignOff {
+		s.WriteParagraph().
+			Text("Every commit must be signed off. The").
+			Code("-s").
+			Text("flag adds a").
+			Code("Signed-off-by").
+			Text("line with your name and email to the commit message.")
+	}
+}
+
+// Defaults returns the props New starts from before applying options, failing when
+// the project url cannot be parsed or the issue tracker url cannot be derived from it.
+func Defaults(projectUrl, issueTrackerUrl string) (ContributingProps, error) {
+	if projectUrl == "" {
+		return ContributingProps{}, fmt.Errorf("projectUrl cannot be empty")
+	}
+
+	repository, err := repourl.ParseProject(projectUrl)
+	if err != nil {
+		return ContributingProps{}, fmt.Errorf("could not extract project name from projectUrl: %w", err)
+	}
+
+	if issueTrackerUrl == "" {
+		issueTrackerUrl, err = repository.IssuesURL()
+		if err != nil {
+			return ContributingProps{}, fmt.Errorf("issueTrackerUrl cannot be empty: %w", err)
+		}
+	}
+
+	return ContributingProps{
+		Name:            DefaultName(),
+		ProjectUrl:      projectUrl,
+		Repository:      repository,
+		IssueTrackerUrl: issueTrackerUrl,
+		DefaultBranch:   metadata.DEFAULT_BRANCH,
+		Profile:         GoProfile(),
+		Conventions:     DefaultConventions(),
+		GettingStarted:  DefaultGettingStarted(),
+		ChoseATask:      DefaultChoseATask(issueTrackerUrl),
+		Setup:           DefaultOpenSourceGoSetupGuidelines(repository.WebURL(), repository.Name),
+		Development:     DefaultOpenSourceDevelopmentGuidelines(GoProfile(), nil, DefaultConventions()),
+		Submissions:     DefaultOpenSourceSubmittingGuidelines(),
+		WritingDocs:     DefaultWritingDocs(),
+		ReportingBugs:   DefaultReportingBugs(issueTrackerUrl),
+		License:         DefaultLicense(),
+	}, nil
+}
+
 // New creates a new contributing guidelines document with default sections for Go projects.
 // Accepts zero or more option functions to customize the document.
 //
 // The projectUrl is used to generate setup instructions and is parsed to extract the project name.
+// HTTPS and SSH URLs are accepted, with or without a ".git" suffix.
 // The issueTrackerUrl is used in task selection and bug reporting sections.
+// If issueTrackerUrl is empty it is derived from the projectUrl, which requires
+// the project to be hosted on GitHub, GitLab or Bitbucket.
 //
 // Example:
 //
@@ -402,62 +900,95 @@ func DefaultOpenSourceSubmittingGuidelines() doyoucompute.Section {
 //		"https://github.com/username/project/issues",
 //		contributing.WithName("How to Contribute"),
 //	)
-func New(projectUrl, issueTrackerUrl string, opts ...doyoucompute.OptionBuilder[contributingProps]) (doyoucompute.Document, error) {
-	if projectUrl == "" {
-		return doyoucompute.Document{}, fmt.Errorf("projectUrl cannot be empty")
-	}
-	if issueTrackerUrl == "" {
-		return doyoucompute.Document{}, fmt.Errorf("issueTrackerUrl cannot be empty")
-	}
-
-	projectNameSplitter := strings.Split(projectUrl, "/")
-	projectName := projectNameSplitter[len(projectNameSplitter)-1]
-
-	// Validate project name was extracted
-	if projectName == "" {
-		return doyoucompute.Document{}, fmt.Errorf("could not extract project name from projectUrl: %s", projectUrl)
-	}
-
-	props := contributingProps{
-		name:            DefaultName(),
-		projectUrl:      projectUrl,
-		issueTrackerUrl: issueTrackerUrl,
-		gettingStarted:  DefaultGettingStarted(),
-		choseATask:      DefaultChoseATask(issueTrackerUrl),
-		setup:           DefaultOpenSourceGoSetupGuidelines(projectUrl, projectName),
-		development:     DefaultOpenSourceGoDevelopmentGuidelines(),
-		submissions:     DefaultOpenSourceSubmittingGuidelines(),
-		writingDocs:     DefaultWritingDocs(),
-		reportingbugs:   DefaultReportingBugs(issueTrackerUrl),
-		license:         DefaultLicense(),
+func New(projectUrl, issueTrackerUrl string, opts ...doyoucompute.OptionBuilder[ContributingProps]) (doyoucompute.Document, error) {
+	props, err := Defaults(projectUrl, issueTrackerUrl)
+	if err != nil {
+		return doyoucompute.Document{}, err
 	}
 
-	err := doyoucompute.ApplyOptions(&props, opts...)
+	err = doyoucompute.ApplyOptions(&props, opts...)
 	if err != nil {
 		return doyoucompute.Document{}, err
 	}
 
 	// Validate props after options applied
-	if props.name == "" {
+	if props.Name == "" {
 		return doyoucompute.Document{}, fmt.Errorf("contributing guide name cannot be empty")
 	}
+	if err := props.Conventions.Commits.Valid(); err != nil {
+		return doyoucompute.Document{}, err
+	}
 
-	return doyoucompute.DocumentFactory(props.name, func(d *doyoucompute.Document) error {
+	return doyoucompute.DocumentFactory(props.Name, func(d *doyoucompute.Document) error {
 		// Apply hierarchy here to improve flexibility of doc content using options
-		props.gettingStarted.AddSection(props.choseATask)
-		d.AddSection(props.gettingStarted)
+		props.GettingStarted.AddSection(props.ChoseATask)
+		d.AddSection(props.GettingStarted)
 
 		guidelines := d.CreateSection("Contribution guidelines")
 		codeContributions := guidelines.CreateSection("Code contributions")
-		codeContributions.AddSection(props.setup)
-		codeContributions.AddSection(props.development)
-		codeContributions.AddSection(props.submissions)
+		codeContributions.AddSection(props.Setup)
+		codeContributions.AddSection(props.Development)
+		codeContributions.AddSection(props.Submissions)
+
+		if props.Review.Name != "" {
+			guidelines.AddSection(props.Review)
+		}
 
-		guidelines.AddSection(props.reportingbugs)
-		guidelines.AddSection(props.writingDocs)
+		if props.Release.Name != "" {
+			guidelines.AddSection(props.Release)
+		}
 
-		d.AddSection(props.license)
+		guidelines.AddSection(props.ReportingBugs)
+		guidelines.AddSection(props.WritingDocs)
+
+		if props.Legal.Name != "" {
+			d.AddSection(props.Legal)
+		}
+
+		d.AddSection(props.License)
+
+		// Edit before the table of contents is built, so it lists the final sections
+		if err := sections.Apply(d, props.Edits...); err != nil {
+			return err
+		}
+
+		if props.TableOfContents > 0 {
+			return toc.Insert(d, props.TableOfContents)
+		}
 
 		return nil
 	})
 }
+
+// NewFromProject creates a new contributing guidelines document from detected project metadata.
+// The project URL comes from the detected repository and the issue tracker URL is derived from it.
+// A recognised license is named by its SPDX identifier in the license section, and the
+// detected default branch is used by the legal section.
+// Accepts zero or more option functions to customize the document.
+//
+// Example:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	doc, err := contributing.NewFromProject(info, contributing.WithName("How to Contribute"))
+func NewFromProject(info metadata.ProjectInfo, opts ...doyoucompute.OptionBuilder[ContributingProps]) (doyoucompute.Document, error) {
+	if !info.HasRepository() {
+		return doyoucompute.Document{}, fmt.Errorf("project info has no repository; add an origin remote or use New")
+	}
+
+	var detected []doyoucompute.OptionBuilder[ContributingProps]
+
+	if info.DefaultBranch != "" {
+		detected = append(detected, WithDefaultBranch(info.DefaultBranch))
+	}
+
+	if info.License.Known() {
+		detected = append(detected, WithLicense(DefaultLicenseFor(info.License.SPDX, info.License.Path)))
+	}
+
+	opts = append(detected, opts...)
+
+	return New(info.Repository.WebURL(), "", opts...)
+}
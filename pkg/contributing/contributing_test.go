@@ -1,10 +1,17 @@
 package contributing
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
 func TestContributing(t *testing.T) {
@@ -15,7 +22,7 @@ func TestContributing(t *testing.T) {
 		name            string
 		projectUrl      string
 		issueTrackerUrl string
-		opts            []doyoucompute.OptionBuilder[contributingProps]
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
 		wantErr         bool
 		wantName        string
 		wantMinContent  int
@@ -33,7 +40,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom name",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithName("Contributing Guidelines"),
 			},
 			wantErr:        false,
@@ -44,7 +51,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom project url",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithProjectUrl("https://github.com/other/repo"),
 			},
 			wantErr:        false,
@@ -55,7 +62,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom issue tracker url",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithIssueTrackerUrl("https://github.com/other/repo/issues"),
 			},
 			wantErr:        false,
@@ -66,7 +73,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom getting started",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithGettingStarted(customSection),
 			},
 			wantErr:        false,
@@ -77,7 +84,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom chose a task",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithChoseATask(customSection),
 			},
 			wantErr:        false,
@@ -88,7 +95,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom setup",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithSetup(customSection),
 			},
 			wantErr:        false,
@@ -99,7 +106,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom development",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithDevelopment(customSection),
 			},
 			wantErr:        false,
@@ -110,7 +117,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom submissions",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithSubmissions(customSection),
 			},
 			wantErr:        false,
@@ -121,7 +128,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom writing docs",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithWritingDocs(customSection),
 			},
 			wantErr:        false,
@@ -132,7 +139,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom reporting bugs",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithReportingbugs(customSection),
 			},
 			wantErr:        false,
@@ -143,7 +150,7 @@ func TestContributing(t *testing.T) {
 			name:            "with custom license",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithLicense(customSection),
 			},
 			wantErr:        false,
@@ -154,7 +161,7 @@ func TestContributing(t *testing.T) {
 			name:            "with multiple options",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithName("How to Contribute"),
 				WithSetup(customSection),
 				WithDevelopment(customSection),
@@ -167,7 +174,7 @@ func TestContributing(t *testing.T) {
 			name:            "with all options",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithName("Complete Contributing Guide"),
 				WithProjectUrl("https://github.com/custom/project"),
 				WithIssueTrackerUrl("https://github.com/custom/project/issues"),
@@ -226,7 +233,7 @@ func TestContributingValidation(t *testing.T) {
 		name            string
 		projectUrl      string
 		issueTrackerUrl string
-		opts            []doyoucompute.OptionBuilder[contributingProps]
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
 		wantErr         bool
 		errMsg          string
 	}{
@@ -238,12 +245,41 @@ func TestContributingValidation(t *testing.T) {
 			errMsg:          "projectUrl cannot be empty",
 		},
 		{
-			name:            "empty issue tracker URL should error",
+			name:            "empty issue tracker URL should be derived",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "",
+			wantErr:         false,
+		},
+		{
+			name:            "empty issue tracker URL on unknown forge should error",
+			projectUrl:      "https://git.example.com/user/project",
+			issueTrackerUrl: "",
 			wantErr:         true,
 			errMsg:          "issueTrackerUrl cannot be empty",
 		},
+		{
+			name:            "project URL without owner should pass",
+			projectUrl:      "https://github.com/project",
+			issueTrackerUrl: "https://github.com/user/project/issues",
+			wantErr:         false,
+		},
+		{
+			name:            "empty issue tracker URL for project URL without owner should error",
+			projectUrl:      "https://github.com/project",
+			issueTrackerUrl: "",
+			wantErr:         true,
+			errMsg:          "issueTrackerUrl cannot be empty",
+		},
+		{
+			name:            "invalid project URL option should error",
+			projectUrl:      "https://github.com/user/project",
+			issueTrackerUrl: "https://github.com/user/project/issues",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithProjectUrl("not a url"),
+			},
+			wantErr: true,
+			errMsg:  "invalid projectUrl",
+		},
 		{
 			name:            "invalid project URL should error",
 			projectUrl:      "https://github.com/",
@@ -255,12 +291,22 @@ func TestContributingValidation(t *testing.T) {
 			name:            "empty name after options should error",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithName(""),
 			},
 			wantErr: true,
 			errMsg:  "name cannot be empty",
 		},
+		{
+			name:            "empty makefile tasks should error",
+			projectUrl:      "https://github.com/user/project",
+			issueTrackerUrl: "https://github.com/user/project/issues",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithMakefileTasks(nil),
+			},
+			wantErr: true,
+			errMsg:  "makefile tasks cannot be empty",
+		},
 		{
 			name:            "valid inputs should pass",
 			projectUrl:      "https://github.com/user/project",
@@ -287,7 +333,7 @@ func TestContributingContent(t *testing.T) {
 		name            string
 		projectUrl      string
 		issueTrackerUrl string
-		opts            []doyoucompute.OptionBuilder[contributingProps]
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
 		wantContains    []string
 		wantNotContains []string
 	}{
@@ -312,7 +358,7 @@ func TestContributingContent(t *testing.T) {
 			name:            "custom section replaces default",
 			projectUrl:      "https://github.com/user/project",
 			issueTrackerUrl: "https://github.com/user/project/issues",
-			opts: []doyoucompute.OptionBuilder[contributingProps]{
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
 				WithSetup(func() doyoucompute.Section {
 					s := doyoucompute.NewSection("Custom Setup")
 					s.WriteParagraph().Text("Use Docker Compose")
@@ -345,6 +391,69 @@ func TestContributingContent(t *testing.T) {
 				"awesome-project",
 			},
 		},
+		{
+			name:            "project name extracted from SSH URL",
+			projectUrl:      "git@github.com:user/awesome-project.git",
+			issueTrackerUrl: "",
+			opts:            nil,
+			wantContains: []string{
+				"git clone <your_fork_url> awesome-project",
+				"[https://github.com/user/awesome-project](https://github.com/user/awesome-project)",
+				"https://github.com/user/awesome-project/issues",
+			},
+			wantNotContains: []string{
+				"awesome-project.git",
+			},
+		},
+		{
+			name:            "project name extracted from URL with trailing slash",
+			projectUrl:      "https://github.com/user/awesome-project/",
+			issueTrackerUrl: "https://github.com/user/awesome-project/issues",
+			opts:            nil,
+			wantContains: []string{
+				"git clone <your_fork_url> awesome-project",
+			},
+		},
+		{
+			name:            "issue tracker derived for GitLab subgroups",
+			projectUrl:      "https://gitlab.com/group/subgroup/project",
+			issueTrackerUrl: "",
+			opts:            nil,
+			wantContains: []string{
+				"git clone <your_fork_url> project",
+				"https://gitlab.com/group/subgroup/project/-/issues",
+			},
+		},
+		{
+			name:            "makefile tasks replace test commands",
+			projectUrl:      "https://github.com/user/project",
+			issueTrackerUrl: "https://github.com/user/project/issues",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithMakefileTasks([]makefile.Target{
+					{Name: "test/unit", Description: "Run tests"},
+					{Name: "fmt", Description: "Format all go files"},
+				}),
+			},
+			wantContains: []string{
+				"Common tasks",
+				"| `make test/unit` | Run tests |",
+				"| `make fmt` | Format all go files |",
+			},
+			wantNotContains: []string{
+				"Run the linters before committing",
+			},
+		},
+		{
+			name:            "project url option updates setup",
+			projectUrl:      "https://github.com/user/project",
+			issueTrackerUrl: "https://github.com/user/project/issues",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithProjectUrl("https://github.com/other/renamed"),
+			},
+			wantContains: []string{
+				"git clone <your_fork_url> renamed",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -468,6 +577,27 @@ func TestDefaultFunctions(t *testing.T) {
 			},
 			wantNil: false,
 		},
+		{
+			name: "DefaultOpenSourceSetupGuidelines",
+			testFunc: func() interface{} {
+				return DefaultOpenSourceSetupGuidelines("https://github.com/user/project", "project", RustProfile())
+			},
+			wantNil: false,
+		},
+		{
+			name: "DefaultOpenSourceDevelopmentGuidelines",
+			testFunc: func() interface{} {
+				return DefaultOpenSourceDevelopmentGuidelines(RustProfile(), nil, DefaultConventions())
+			},
+			wantNil: false,
+		},
+		{
+			name: "DefaultCommonTasks",
+			testFunc: func() interface{} {
+				return DefaultCommonTasks([]makefile.Target{{Name: "test", Description: "Runs tests"}})
+			},
+			wantNil: false,
+		},
 		{
 			name: "DefaultOpenSourceSubmittingGuidelines",
 			testFunc: func() interface{} {
@@ -489,3 +619,283 @@ func TestDefaultFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFromProject(t *testing.T) {
+	repository, err := repourl.Parse("git@github.com:user/detected.git")
+	if err != nil {
+		t.Fatalf("repourl.Parse() error = %v", err)
+	}
+
+	doc, err := NewFromProject(metadata.ProjectInfo{Repository: repository})
+	if err != nil {
+		t.Fatalf("NewFromProject() error = %v", err)
+	}
+
+	renderer := doyoucompute.NewMarkdownRenderer()
+	rendered, err := renderer.Render(&doc)
+	if err != nil {
+		t.Fatalf("renderer.Render() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"https://github.com/user/detected/issues",
+		"git clone <your_fork_url> detected",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("renderer.Render() missing expected content: %q", want)
+		}
+	}
+
+	_, err = NewFromProject(metadata.ProjectInfo{})
+	if err == nil || !strings.Contains(err.Error(), "no repository") {
+		t.Errorf("NewFromProject() error = %v, want error about missing repository", err)
+	}
+}
+
+func TestContributingTableOfContents(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+		wantErr         string
+	}{
+		{
+			name:            "no table of contents by default",
+			wantNotContains: []string{"## Table of contents"},
+		},
+		{
+			name: "depth limits nesting",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTableOfContents(2),
+			},
+			wantContains: []string{
+				"# Contributing\n\n## Table of contents\n\n- [Getting started](#getting-started)\n  - [Find a task](#find-a-task)\n- [Contribution guidelines](#contribution-guidelines)\n  - [Code contributions](#code-contributions)",
+			},
+			wantNotContains: []string{"(#development-workflow)"},
+		},
+		{
+			name: "full depth includes optional sections",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithDCO(),
+				WithTableOfContents(3),
+			},
+			wantContains: []string{
+				"    - [Development Workflow](#development-workflow)",
+				"- [Legal](#legal)\n  - [Developer Certificate of Origin](#developer-certificate-of-origin)",
+			},
+		},
+		{
+			name: "invalid depth",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTableOfContents(-1),
+			},
+			wantErr: "depth must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestContributingSections(t *testing.T) {
+	security := doyoucompute.NewSection("Reporting security issues")
+	security.WriteParagraph().Text("Email security@example.com instead of opening an issue.")
+
+	tests := []struct {
+		name         string
+		opts         []doyoucompute.OptionBuilder[ContributingProps]
+		wantSections []string
+		wantErr      string
+	}{
+		{
+			name: "insert into a nested section",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithSections(
+					sections.InsertAfter("Reporting new bugs", security),
+					sections.Remove("Writing documentation"),
+				),
+			},
+			wantSections: []string{
+				"Getting started", "Find a task",
+				"Contribution guidelines", "Code contributions", "Setting Up Your Development Environment", "Development Workflow", "Submitting your changes",
+				"Reporting bugs", "Checking for Existing Reports", "Reporting new bugs", "Reporting security issues",
+				"License",
+			},
+		},
+		{
+			name: "edits run before the table of contents is built",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTableOfContents(1),
+				WithSections(sections.Remove("Reporting bugs"), sections.Remove("Writing documentation")),
+			},
+			wantSections: []string{
+				"Table of contents",
+				"Getting started", "Find a task",
+				"Contribution guidelines", "Code contributions", "Setting Up Your Development Environment", "Development Workflow", "Submitting your changes",
+				"License",
+			},
+		},
+		{
+			name: "unknown section",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithSections(sections.Remove("Code of conduct")),
+			},
+			wantErr: `"Code of conduct" in Contributing`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if tt.wantErr != "" {
+				if !errors.Is(err, sections.ErrUnknownSection) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should be sections.ErrUnknownSection containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got := sections.Names(doc); !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("sections.Names() = %q, want %q", got, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	tests := []struct {
+		name                string
+		projectUrl          string
+		issueTrackerUrl     string
+		wantIssueTrackerUrl string
+		wantErr             string
+	}{
+		{
+			name:                "issue tracker derived from the project",
+			projectUrl:          "https://github.com/user/project",
+			wantIssueTrackerUrl: "https://github.com/user/project/issues",
+		},
+		{
+			name:                "issue tracker given",
+			projectUrl:          "https://github.com/user/project",
+			issueTrackerUrl:     "https://tracker.example.com/project",
+			wantIssueTrackerUrl: "https://tracker.example.com/project",
+		},
+		{
+			name:    "missing project url",
+			wantErr: "projectUrl cannot be empty",
+		},
+		{
+			name:       "issue tracker cannot be derived",
+			projectUrl: "https://git.example.com/user/project",
+			wantErr:    "issueTrackerUrl cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props, err := Defaults(tt.projectUrl, tt.issueTrackerUrl)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Defaults() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Defaults() error = %v", err)
+			}
+
+			if props.Name != DefaultName() {
+				t.Errorf("Defaults().Name = %q, want %q", props.Name, DefaultName())
+			}
+			if props.IssueTrackerUrl != tt.wantIssueTrackerUrl {
+				t.Errorf("Defaults().IssueTrackerUrl = %q, want %q", props.IssueTrackerUrl, tt.wantIssueTrackerUrl)
+			}
+			if props.Repository.Name != "project" {
+				t.Errorf("Defaults().Repository.Name = %q, want %q", props.Repository.Name, "project")
+			}
+		})
+	}
+}
+
+func TestCustomOption(t *testing.T) {
+	// Adds a command to whichever profile is in use, delegating to WithProfile so dependent sections are rebuilt
+	withExtraTest := func(command string) Option {
+		return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+			profile := p.Profile
+			profile.Test = append(slices.Clone(profile.Test), command)
+
+			return WithProfile(profile)(p)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantContains []string
+	}{
+		{
+			name:         "applies to the default profile",
+			opts:         []Option{withExtraTest("make test/integration")},
+			wantContains: []string{"go test ./...", "make test/integration"},
+		},
+		{
+			name:         "applies to the profile set before it",
+			opts:         []Option{WithProfile(RustProfile()), withExtraTest("cargo test --doc")},
+			wantContains: []string{"cargo test", "cargo test --doc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			rendered, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() = %q, should contain %q", rendered, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package contributing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BranchScheme describes how contributors name their branches.
+type BranchScheme struct {
+	// Pattern is the naming pattern shown to contributors, e.g. "<type>/<issue>-<description>".
+	// An empty pattern means branches can be named freely.
+	Pattern string
+	// Example is a branch name that follows the pattern
+	Example string
+}
+
+// DefaultBranchScheme returns a free-form branch scheme.
+func DefaultBranchScheme() BranchScheme {
+	return BranchScheme{
+		Example: "feature/my-awesome-feature",
+	}
+}
+
+// TypedBranchScheme returns a scheme that prefixes branches with the type of change and the issue number.
+func TypedBranchScheme() BranchScheme {
+	return BranchScheme{
+		Pattern: "<type>/<issue-number>-<short-description>",
+		Example: "fix/123-handle-empty-input",
+	}
+}
+
+// CommitConvention describes how contributors write commit messages.
+type CommitConvention struct {
+	// Name of the convention, empty for free-form messages
+	Name string
+	// Url links to the convention's specification
+	Url string
+	// Types lists the allowed commit types
+	Types []string
+	// Examples are commit messages that follow the convention, the first is used in commands
+	Examples []string
+}
+
+// FreeFormCommits returns a convention that only asks for descriptive messages.
+func FreeFormCommits() CommitConvention {
+	return CommitConvention{
+		Examples: []string{"Add feature: descriptive commit message"},
+	}
+}
+
+// ConventionalCommits returns the Conventional Commits convention.
+func ConventionalCommits() CommitConvention {
+	return CommitConvention{
+		Name:  "Conventional Commits",
+		Url:   "https://www.conventionalcommits.org/en/v1.0.0/",
+		Types: []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"},
+		Examples: []string{
+			"feat(parser): support trailing commas",
+			"fix: handle empty input",
+		},
+	}
+}
+
+// Gitmoji returns the gitmoji convention.
+func Gitmoji() CommitConvention {
+	return CommitConvention{
+		Name:  "gitmoji",
+		Url:   "https://gitmoji.dev",
+		Types: []string{":sparkles:", ":bug:", ":memo:", ":recycle:", ":white_check_mark:", ":wrench:"},
+		Examples: []string{
+			":sparkles: Support trailing commas",
+			":bug: Handle empty input",
+		},
+	}
+}
+
+// Valid returns an error if the convention cannot be rendered.
+func (c CommitConvention) Valid() error {
+	if len(c.Examples) == 0 {
+		return errors.New("commit convention must include at least one example")
+	}
+
+	return nil
+}
+
+// MergePolicy describes how pull requests are merged.
+type MergePolicy int
+
+const (
+	// UnspecifiedMerge leaves the merge strategy out of the guidelines
+	UnspecifiedMerge MergePolicy = iota
+	// SquashMerge squashes every pull request into a single commit
+	SquashMerge
+	// MergeCommit merges pull requests with a merge commit
+	MergeCommit
+	// RebaseMerge rebases the pull request commits onto the target branch
+	RebaseMerge
+)
+
+// Description returns the sentence used to explain the policy to contributors, for pull
+// requests against branch.
+func (m MergePolicy) Description(branch string) string {
+	switch m {
+	case SquashMerge:
+		return "Once approved, your pull request is squash merged, so the pull request title becomes the commit message"
+	case MergeCommit:
+		return "Once approved, your pull request is merged with a merge commit, so keep your commit history clean"
+	case RebaseMerge:
+		return fmt.Sprintf("Once approved, your commits are rebased onto the %s branch, so each commit should stand on its own", branch)
+	}
+
+	return ""
+}
+
+// Conventions groups the branching, commit and merge settings rendered in the
+// development and submission sections.
+type Conventions struct {
+	// Branching is the branch naming scheme
+	Branching BranchScheme
+	// Commits is the commit message convention
+	Commits CommitConvention
+	// SignOff requires commits to carry a Signed-off-by trailer
+	SignOff bool
+	// Merge is the pull request merge policy
+	Merge MergePolicy
+}
+
+// DefaultConventions returns free-form branching and commit conventions.
+func DefaultConventions() Conventions {
+	return Conventions{
+		Branching: DefaultBranchScheme(),
+		Commits:   FreeFormCommits(),
+	}
+}
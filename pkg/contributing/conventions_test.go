@@ -0,0 +1,180 @@
+package contributing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+func TestConventionsRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "defaults keep free-form conventions",
+			opts: nil,
+			wantContains: []string{
+				"git checkout -b feature/my-awesome-feature",
+				"git commit -m \"Add feature: descriptive commit message\"",
+				"git push origin feature/my-awesome-feature",
+			},
+			wantNotContains: []string{
+				"Allowed types are",
+				"signed off",
+				"Once approved",
+			},
+		},
+		{
+			name: "typed branch scheme",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithBranchScheme(TypedBranchScheme()),
+			},
+			wantContains: []string{
+				"named `<type>/<issue-number>-<short-description>`",
+				"git checkout -b fix/123-handle-empty-input",
+				"git push origin fix/123-handle-empty-input",
+			},
+		},
+		{
+			name: "conventional commits",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithCommitConvention(ConventionalCommits()),
+			},
+			wantContains: []string{
+				"[Conventional Commits](https://www.conventionalcommits.org/en/v1.0.0/)",
+				"Allowed types are `feat`, `fix`, `docs`",
+				"git commit -m \"feat(parser): support trailing commas\"",
+				"`fix: handle empty input`",
+			},
+		},
+		{
+			name: "gitmoji",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithCommitConvention(Gitmoji()),
+			},
+			wantContains: []string{
+				"[gitmoji](https://gitmoji.dev)",
+				"git commit -m \":sparkles: Support trailing commas\"",
+			},
+		},
+		{
+			name: "sign-off required",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithSignOff(),
+			},
+			wantContains: []string{
+				"git commit -s -m",
+				"Every commit must be signed off.",
+			},
+		},
+		{
+			name: "squash merge",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithMergePolicy(SquashMerge),
+			},
+			wantContains: []string{
+				"squash merged",
+			},
+		},
+		{
+			name: "rebase merge names the default branch",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithMergePolicy(RebaseMerge),
+				WithDefaultBranch("trunk"),
+			},
+			wantContains: []string{
+				"rebased onto the trunk branch",
+			},
+			wantNotContains: []string{
+				"main branch",
+			},
+		},
+		{
+			name: "conventions survive later profile change",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithBranchScheme(TypedBranchScheme()),
+				WithProfile(RustProfile()),
+			},
+			wantContains: []string{
+				"git checkout -b fix/123-handle-empty-input",
+				"cargo test",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestConventionsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []doyoucompute.OptionBuilder[ContributingProps]
+		errMsg string
+	}{
+		{
+			name:   "branch scheme without example",
+			opts:   []doyoucompute.OptionBuilder[ContributingProps]{WithBranchScheme(BranchScheme{Pattern: "<type>/<description>"})},
+			errMsg: "branch scheme example cannot be empty",
+		},
+		{
+			name:   "commit convention without examples",
+			opts:   []doyoucompute.OptionBuilder[ContributingProps]{WithCommitConvention(CommitConvention{Name: "Custom"})},
+			errMsg: "at least one example",
+		},
+		{
+			name: "commit convention without examples set by a custom option",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+					p.Conventions.Commits = CommitConvention{Name: "Custom"}
+
+					return nil, nil
+				},
+				WithSignOff(),
+			},
+			errMsg: "at least one example",
+		},
+		{
+			name:   "unknown merge policy",
+			opts:   []doyoucompute.OptionBuilder[ContributingProps]{WithMergePolicy(MergePolicy(42))},
+			errMsg: "unknown merge policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("https://github.com/user/project", "", tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("New() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package contributing
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+)
+
+// DEFAULT_LICENSE_PATH is the license file linked from the license section when no other path is given.
+const DEFAULT_LICENSE_PATH = "./LICENSE"
+
+// DCO_URL links to the Developer Certificate of Origin.
+const DCO_URL = "https://developercertificate.org"
+
+// DCO_TEXT is version 1.1 of the Developer Certificate of Origin.
+const DCO_TEXT = `Developer Certificate of Origin
+Version 1.1
+
+Copyright (C) 2004, 2006 The Linux Foundation and its contributors.
+
+Everyone is permitted to copy and distribute verbatim copies of this
+license document, but changing it is not allowed.
+
+
+Developer's Certificate of Origin 1.1
+
+By making a contribution to this project, I certify that:
+
+(a) The contribution was created in whole or in part by me and I
+    have the right to submit it under the open source license
+    indicated in the file; or
+
+(b) The contribution is based upon previous work that, to the best
+    of my knowledge, is covered under an appropriate open source
+    license and I have the right under that license to submit that
+    work with modifications, whether created in whole or in part
+    by me, under the same open source license (unless I am
+    permitted to submit under a different license), as indicated
+    in the file; or
+
+(c) The contribution was provided directly to me by some other
+    person who certified (a), (b) or (c) and I have not modified
+    it.
+
+(d) I understand and agree that this project and the contribution
+    are public and that a record of the contribution (including all
+    personal information I submit with it, including my sign-off) is
+    maintained indefinitely and may be redistributed consistent with
+    this project or the open source license(s) involved.`
+
+// CLA describes a Contributor License Agreement contributors must sign.
+type CLA struct {
+	// Url links to the agreement or the page where it is signed
+	Url string
+	// Process describes how signing works, e.g. which bot checks for a signature
+	Process string
+}
+
+// DefaultCLAProcess returns the default description of the CLA signing process.
+func DefaultCLAProcess() string {
+	return "A bot checks every pull request and comments with a link to sign if you haven't signed yet. You only need to sign once."
+}
+
+var spdxPattern = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// WithDCO requires contributors to sign off their commits under the Developer Certificate of Origin.
+// This adds a legal section with the DCO text and requires sign-off in the submissions section.
+//
+// Example:
+//
+//	contributing.WithDCO()
+func WithDCO() doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		p.DCO = true
+		p.Conventions.SignOff = true
+
+		return func(p *ContributingProps) error {
+			p.Legal = p.presetLegal()
+
+			return rebuildConventionSections(p)
+		}, nil
+	}
+}
+
+// WithCLA requires contributors to sign a Contributor License Agreement before their changes are merged.
+// If process is empty DefaultCLAProcess is used.
+//
+// Example:
+//
+//	contributing.WithCLA("https://cla.example.com/project", "")
+func WithCLA(url, process string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if url == "" {
+			return nil, fmt.Errorf("CLA url cannot be empty")
+		}
+		if process == "" {
+			process = DefaultCLAProcess()
+		}
+
+		p.CLA = CLA{Url: url, Process: process}
+
+		return func(p *ContributingProps) error {
+			p.Legal = p.presetLegal()
+
+			return nil
+		}, nil
+	}
+}
+
+// WithLicenseID names the project's license by its SPDX identifier in the license section,
+// linking to the license file at path. If path is empty DEFAULT_LICENSE_PATH is used.
+//
+// Example:
+//
+//	contributing.WithLicenseID("Apache-2.0", "./LICENSE.md")
+func WithLicenseID(spdx, path string) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if !spdxPattern.MatchString(spdx) {
+			return nil, fmt.Errorf("invalid SPDX license identifier: %q", spdx)
+		}
+		if path == "" {
+			path = DEFAULT_LICENSE_PATH
+		}
+
+		p.License = DefaultLicenseFor(spdx, path)
+
+		return nil, nil
+	}
+}
+
+// DefaultLicenseFor returns a license section that names the license by its SPDX identifier.
+func DefaultLicenseFor(spdx, path string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("License", func(s *doyoucompute.Section) error {
+		s.WriteParagraph().
+			Text("By contributing, you agree that your contributions will be licensed under the project's").
+			Link(fmt.Sprintf("%s License.", spdx), path)
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultLegal returns the legal section for the DCO and CLA requirements of a project
+// cloned from cloneUrl. Returns an empty section if neither is required.
+func DefaultLegal(dco bool, cla CLA, cloneUrl string) doyoucompute.Section {
+	var dcoSection doyoucompute.Section
+	if dco {
+		dcoSection = DefaultDCO(cloneUrl)
+	}
+
+	return legalSection(dcoSection, cla)
+}
+
+// presetLegal builds the legal section, with a sign-off fix-up for the selected preset and default branch.
+func (p *ContributingProps) presetLegal() doyoucompute.Section {
+	var dcoSection doyoucompute.Section
+	if p.DCO {
+		dcoSection = DefaultDCOFor(p.Preset, p.Repository.CloneURL(), p.DefaultBranch)
+	}
+
+	return legalSection(dcoSection, p.CLA)
+}
+
+func legalSection(dco doyoucompute.Section, cla CLA) doyoucompute.Section {
+	if dco.Name == "" && cla.Url == "" {
+		return doyoucompute.Section{}
+	}
+
+	section, _ := doyoucompute.SectionFactory("Legal", func(s *doyoucompute.Section) error {
+		if dco.Name != "" {
+			s.AddSection(dco)
+		}
+
+		if cla.Url != "" {
+			s.AddSection(DefaultCLA(cla))
+		}
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultDCO returns the Developer Certificate of Origin section for a project contributed
+// to through forks of cloneUrl, whose default branch is main. Use DefaultDCOFor for other
+// workflows and branches.
+func DefaultDCO(cloneUrl string) doyoucompute.Section {
+	return DefaultDCOFor(OpenSourcePreset, cloneUrl, metadata.DEFAULT_BRANCH)
+}
+
+// DefaultDCOFor returns the Developer Certificate of Origin section, telling contributors
+// to sign off forgotten commits by rebasing onto branch. Forks rebase onto the upstream
+// remote, added from cloneUrl, as their origin remote is the fork itself.
+func DefaultDCOFor(preset Preset, cloneUrl, branch string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Developer Certificate of Origin", func(s *doyoucompute.Section) error {
+		s.WriteParagraph().
+			Text("This project uses the").
+			Link("Developer Certificate of Origin", DCO_URL).
+			Text("(DCO). By signing off your commits you certify the following:")
+
+		s.WriteCodeBlock("text", []string{DCO_TEXT}, doyoucompute.Static)
+
+		s.WriteParagraph().
+			Text("Pass").
+			Code("-s").
+			Text("to").
+			Code("git commit").
+			Text("on every commit to add a").
+			Code("Signed-off-by").
+			Text("line with your configured name and email:")
+
+		s.WriteCodeBlock("bash", []string{"git commit -s -m \"Add feature: descriptive commit message\""}, doyoucompute.Static)
+
+		s.WriteParagraph().
+			Text("If you forgot to sign off, amend your commits before pushing:")
+
+		if preset == InternalPreset {
+			writeCommands(s, []string{"git fetch origin", fmt.Sprintf("git rebase --signoff origin/%s", branch)})
+
+			return nil
+		}
+
+		writeCommands(s, []string{
+			fmt.Sprintf("git remote add upstream %s", cloneUrl),
+			"git fetch upstream",
+			fmt.Sprintf("git rebase --signoff upstream/%s", branch),
+		})
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultCLA returns the Contributor License Agreement section.
+func DefaultCLA(cla CLA) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Contributor License Agreement", func(s *doyoucompute.Section) error {
+		s.WriteParagraph().
+			Text("Before we can merge your contribution you must sign the").
+			Link("Contributor License Agreement", cla.Url).
+			Text("(CLA).")
+
+		s.WriteParagraph().Text(cla.Process)
+
+		return nil
+	})
+
+	return section
+}
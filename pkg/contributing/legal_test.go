@@ -0,0 +1,245 @@
+package contributing
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+func TestLegalRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "no legal section by default",
+			opts: nil,
+			wantContains: []string{
+				"[License.](./LICENSE)",
+			},
+			wantNotContains: []string{
+				"## Legal",
+				"Developer Certificate of Origin",
+				"Contributor License Agreement",
+			},
+		},
+		{
+			name: "dco requires sign-off",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithDCO(),
+			},
+			wantContains: []string{
+				"## Legal",
+				"### Developer Certificate of Origin",
+				"[Developer Certificate of Origin](https://developercertificate.org)",
+				"Developer's Certificate of Origin 1.1",
+				"git commit -s -m",
+				"git remote add upstream https://github.com/user/project.git",
+				"git rebase --signoff upstream/main",
+				"Every commit must be signed off.",
+			},
+			wantNotContains: []string{
+				"Contributor License Agreement",
+				"origin/main",
+			},
+		},
+		{
+			name: "dco fix-up rebases onto the default branch",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithDCO(),
+				WithDefaultBranch("trunk"),
+			},
+			wantContains: []string{
+				"git rebase --signoff upstream/trunk",
+			},
+		},
+		{
+			name: "dco fix-up on a shared repository rebases onto origin",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithDefaultBranch("develop"),
+				WithDCO(),
+				WithPreset(InternalPreset),
+			},
+			wantContains: []string{
+				"git fetch origin",
+				"git rebase --signoff origin/develop",
+			},
+			wantNotContains: []string{
+				"upstream",
+			},
+		},
+		{
+			name: "cla with default process",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithCLA("https://cla.example.com/project", ""),
+			},
+			wantContains: []string{
+				"### Contributor License Agreement",
+				"[Contributor License Agreement](https://cla.example.com/project)",
+				DefaultCLAProcess(),
+			},
+			wantNotContains: []string{
+				"Developer Certificate of Origin",
+			},
+		},
+		{
+			name: "dco and cla together",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithCLA("https://cla.example.com/project", "Sign the CLA through our portal."),
+				WithDCO(),
+			},
+			wantContains: []string{
+				"### Developer Certificate of Origin",
+				"### Contributor License Agreement",
+				"Sign the CLA through our portal.",
+			},
+		},
+		{
+			name: "license names spdx identifier",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithLicenseID("Apache-2.0", ""),
+			},
+			wantContains: []string{
+				"[Apache-2.0 License.](./LICENSE)",
+			},
+		},
+		{
+			name: "license links the given path",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithLicenseID("Apache-2.0", "./docs/LICENSE.md"),
+			},
+			wantContains: []string{
+				"[Apache-2.0 License.](./docs/LICENSE.md)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultLegalMatchesDefaultPreset(t *testing.T) {
+	props, err := Defaults("https://github.com/user/project", "")
+	if err != nil {
+		t.Fatalf("Defaults() error = %v", err)
+	}
+	props.DCO = true
+
+	got := DefaultLegal(true, CLA{}, "https://github.com/user/project.git")
+	if want := props.presetLegal(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultLegal() = %v, want %v", got, want)
+	}
+}
+
+func TestLegalValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    doyoucompute.OptionBuilder[ContributingProps]
+		errMsg string
+	}{
+		{
+			name:   "cla without url",
+			opt:    WithCLA("", "Sign it"),
+			errMsg: "CLA url cannot be empty",
+		},
+		{
+			name:   "empty license id",
+			opt:    WithLicenseID("", ""),
+			errMsg: "invalid SPDX license identifier",
+		},
+		{
+			name:   "license id with spaces",
+			opt:    WithLicenseID("Apache 2.0", ""),
+			errMsg: "invalid SPDX license identifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("https://github.com/user/project", "", tt.opt)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("New() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestNewFromProjectLicense(t *testing.T) {
+	repository, err := repourl.Parse("https://github.com/user/project")
+	if err != nil {
+		t.Fatalf("repourl.Parse() error = %v", err)
+	}
+
+	info := metadata.ProjectInfo{
+		Repository:    repository,
+		DefaultBranch: "trunk",
+		License:       metadata.License{SPDX: "MPL-2.0", Name: "Mozilla Public License 2.0", Path: "./LICENSE.md"},
+	}
+
+	tests := []struct {
+		name string
+		opts []doyoucompute.OptionBuilder[ContributingProps]
+		want string
+	}{
+		{name: "detected license", want: "[MPL-2.0 License.](./LICENSE.md)"},
+		{
+			name: "option overrides detected license",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{WithLicenseID("MIT", "")},
+			want: "[MIT License.](./LICENSE)",
+		},
+		{
+			name: "detected default branch",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{WithDCO()},
+			want: "git rebase --signoff upstream/trunk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewFromProject(info, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewFromProject() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			if !strings.Contains(rendered, tt.want) {
+				t.Errorf("renderer.Render() missing expected content: %q", tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package contributing
+
+import (
+	"fmt"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+)
+
+// Preset selects the contribution workflow rendered in the setup, development and submissions sections.
+type Preset int
+
+const (
+	// OpenSourcePreset is the fork-and-pull-request workflow used by public projects
+	OpenSourcePreset Preset = iota
+	// InternalPreset is a trunk-based workflow where contributors branch directly
+	// on a shared repository with a protected default branch and required CI checks
+	InternalPreset
+)
+
+// String returns the name of the preset.
+func (p Preset) String() string {
+	switch p {
+	case OpenSourcePreset:
+		return "open-source"
+	case InternalPreset:
+		return "internal"
+	}
+
+	return fmt.Sprintf("Preset(%d)", int(p))
+}
+
+// WithPreset selects the contribution workflow and updates the setup, development and submissions sections.
+// Profiles, Makefile tasks and conventions apply to either preset.
+//
+// Example:
+//
+//	contributing.WithPreset(contributing.InternalPreset)
+func WithPreset(preset Preset) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if preset < OpenSourcePreset || preset > InternalPreset {
+			return nil, fmt.Errorf("unknown preset: %d", preset)
+		}
+
+		p.Preset = preset
+
+		return func(p *ContributingProps) error {
+			p.Setup = p.presetSetup()
+			p.Development = p.presetDevelopment()
+			p.Submissions = p.presetSubmissions()
+
+			if p.DCO {
+				p.Legal = p.presetLegal()
+			}
+
+			return nil
+		}, nil
+	}
+}
+
+// presetSetup builds the setup section for the selected preset.
+func (p *ContributingProps) presetSetup() doyoucompute.Section {
+	if p.Preset == InternalPreset {
+		return DefaultInternalSetupGuidelines(p.Repository.CloneURL(), p.Repository.Name, p.Profile)
+	}
+
+	return DefaultOpenSourceSetupGuidelines(p.Repository.WebURL(), p.Repository.Name, p.Profile)
+}
+
+// presetDevelopment builds the development section for the selected preset.
+func (p *ContributingProps) presetDevelopment() doyoucompute.Section {
+	if p.Preset == InternalPreset {
+		return DefaultInternalDevelopmentGuidelines(p.Profile, p.Tasks, p.Conventions, p.DefaultBranch)
+	}
+
+	return DefaultOpenSourceDevelopmentGuidelines(p.Profile, p.Tasks, p.Conventions)
+}
+
+// presetSubmissions builds the submissions section for the selected preset.
+func (p *ContributingProps) presetSubmissions() doyoucompute.Section {
+	if p.Preset == InternalPreset {
+		return DefaultInternalSubmittingGuidelinesFor(p.Conventions, p.DefaultBranch)
+	}
+
+	return DefaultOpenSourceSubmittingGuidelinesFor(p.Conventions, p.DefaultBranch)
+}
+
+// DefaultInternalSetupGuidelines returns the setup section for contributors with write access to the repository.
+func DefaultInternalSetupGuidelines(cloneUrl string, projectName string, profile Profile) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Setting Up Your Development Environment", func(s *doyoucompute.Section) error {
+		s.WriteParagraph().
+			Text("Clone the repository directly, there is no need to fork it:")
+
+		s.WriteCodeBlock("bash", []string{fmt.Sprintf("git clone %s %s", cloneUrl, projectName)}, doyoucompute.Static)
+		s.WriteCodeBlock("bash", []string{fmt.Sprintf("cd %s", projectName)}, doyoucompute.Static)
+
+		s.WriteParagraph().
+			Text("Install dependencies and verify you can run the tests:")
+
+		writeCommands(s, profile.Install)
+		writeCommands(s, profile.Test)
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultInternalDevelopmentGuidelines returns the development workflow section for a shared repository
+// whose default branch is protected.
+func DefaultInternalDevelopmentGuidelines(profile Profile, tasks []makefile.Target, conventions Conventions, branch string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Development Workflow", func(s *doyoucompute.Section) error {
+		s.WriteIntro().
+			Text(fmt.Sprintf("The %s branch is protected, so every change goes through a pull request from a short-lived branch on this repository.", branch))
+
+		writeDevelopmentWorkflow(s, profile, tasks, conventions)
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultInternalSubmittingGuidelinesFor returns the submission guidelines section for a shared repository
+// where pull requests against the default branch must pass the required CI checks before merging.
+func DefaultInternalSubmittingGuidelinesFor(conventions Conventions, branch string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Submitting your changes", func(s *doyoucompute.Section) error {
+		writeCommitGuidelines(s, conventions)
+
+		s.WriteParagraph().
+			Text("Push your branch to the repository:")
+
+		s.WriteCodeBlock("bash", []string{fmt.Sprintf("git push -u origin %s", conventions.Branching.Example)}, doyoucompute.Static)
+
+		s.WriteParagraph().
+			Text("Finally, open a pull request:")
+
+		submissionSteps := s.CreateList(doyoucompute.BULLET)
+		submissionSteps.Append(fmt.Sprintf("Open a pull request from your branch against the %s branch", branch))
+		submissionSteps.Append("Provide a clear description of your changes")
+		submissionSteps.Append("Reference any relevant issues using #issue-number")
+		submissionSteps.Append("Wait for the required CI checks to pass, pull requests cannot be merged while checks are failing")
+		submissionSteps.Append("Wait for review and address any feedback")
+
+		if policy := conventions.Merge.Description(branch); policy != "" {
+			submissionSteps.Append(policy)
+		}
+
+		return nil
+	})
+
+	return section
+}
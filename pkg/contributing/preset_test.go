@@ -0,0 +1,152 @@
+package contributing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+)
+
+func TestPresetRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "open source preset by default",
+			opts: nil,
+			wantContains: []string{
+				"fork the repository on GitHub",
+				"git clone <your_fork_url> project",
+				"Push your changes to your forked repository:",
+				"Click \"Compare & pull request\"",
+			},
+			wantNotContains: []string{
+				"protected",
+				"required CI checks",
+			},
+		},
+		{
+			name: "internal preset",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithPreset(InternalPreset),
+			},
+			wantContains: []string{
+				"Clone the repository directly, there is no need to fork it:",
+				"git clone https://github.com/user/project.git project",
+				"The main branch is protected",
+				"git checkout -b feature/my-awesome-feature",
+				"git push -u origin feature/my-awesome-feature",
+				"Open a pull request from your branch against the main branch",
+				"Wait for the required CI checks to pass",
+			},
+			wantNotContains: []string{
+				"Fork",
+				"your_fork_url",
+				"forked repository",
+			},
+		},
+		{
+			name: "internal preset keeps profile, tasks and conventions",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithProfile(RustProfile()),
+				WithMakefileTasks([]makefile.Target{{Name: "test", Description: "Run tests"}}),
+				WithPreset(InternalPreset),
+				WithBranchScheme(TypedBranchScheme()),
+				WithMergePolicy(SquashMerge),
+			},
+			wantContains: []string{
+				"cargo build",
+				"`make test`",
+				"git push -u origin fix/123-handle-empty-input",
+				"squash merged",
+			},
+		},
+		{
+			name: "internal preset names the default branch",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithPreset(InternalPreset),
+				WithDefaultBranch("trunk"),
+				WithDCO(),
+			},
+			wantContains: []string{
+				"The trunk branch is protected",
+				"Open a pull request from your branch against the trunk branch",
+				"git rebase --signoff origin/trunk",
+			},
+			wantNotContains: []string{
+				"main branch",
+				"origin/main",
+			},
+		},
+		{
+			name: "switching back to open source preset",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithPreset(InternalPreset),
+				WithPreset(OpenSourcePreset),
+			},
+			wantContains: []string{
+				"git clone <your_fork_url> project",
+			},
+			wantNotContains: []string{
+				"protected",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestPresetValidation(t *testing.T) {
+	_, err := New("https://github.com/user/project", "", WithPreset(Preset(7)))
+	if err == nil || !strings.Contains(err.Error(), "unknown preset") {
+		t.Errorf("New() error = %v, should contain %q", err, "unknown preset")
+	}
+}
+
+func TestPresetString(t *testing.T) {
+	tests := []struct {
+		preset Preset
+		want   string
+	}{
+		{preset: OpenSourcePreset, want: "open-source"},
+		{preset: InternalPreset, want: "internal"},
+		{preset: Preset(7), want: "Preset(7)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.preset.String(); got != tt.want {
+				t.Errorf("Preset.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package contributing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// ReviewProcess describes how pull requests are reviewed.
+type ReviewProcess struct {
+	// RequiredApprovals is the number of approving reviews needed before merging
+	RequiredApprovals int
+	// CodeOwners reports whether reviewers are requested from a CODEOWNERS file
+	CodeOwners bool
+	// Turnaround sets expectations for how quickly reviews happen
+	Turnaround string
+	// ReRequest explains how to ask for another review after addressing feedback
+	ReRequest string
+}
+
+// DefaultReviewProcess returns a review process requiring a single approval.
+func DefaultReviewProcess() ReviewProcess {
+	return ReviewProcess{
+		RequiredApprovals: 1,
+		Turnaround:        "Maintainers aim to review pull requests within a few business days",
+		ReRequest:         "Once you have addressed feedback, push your changes and re-request review from the reviewers on the pull request",
+	}
+}
+
+// Valid returns an error if the review process cannot be rendered.
+func (r ReviewProcess) Valid() error {
+	if r.RequiredApprovals < 0 {
+		return fmt.Errorf("required approvals cannot be negative: %d", r.RequiredApprovals)
+	}
+
+	return nil
+}
+
+// ReleaseProcess describes how new versions are released.
+type ReleaseProcess struct {
+	// Versioning is the name of the versioning scheme, e.g. "Semantic Versioning"
+	Versioning string
+	// VersioningUrl links to the versioning scheme's specification
+	VersioningUrl string
+	// Releasers describes who cuts releases and how
+	Releasers string
+	// Changelog describes what contributors need to do for the changelog
+	Changelog string
+}
+
+// DefaultReleaseProcess returns a semantic versioning release process cut by maintainers.
+func DefaultReleaseProcess() ReleaseProcess {
+	return ReleaseProcess{
+		Versioning:    "Semantic Versioning",
+		VersioningUrl: "https://semver.org",
+		Releasers:     "Maintainers cut releases by tagging the main branch",
+		Changelog:     "Describe user-facing changes in your pull request description so they can be included in the release notes",
+	}
+}
+
+// Valid returns an error if the release process cannot be rendered.
+func (r ReleaseProcess) Valid() error {
+	if r.Versioning == "" {
+		return errors.New("release process versioning scheme cannot be empty")
+	}
+
+	return nil
+}
+
+// WithReviewProcess adds a code review section to the contribution guidelines.
+//
+// Example:
+//
+//	review := contributing.DefaultReviewProcess()
+//	review.RequiredApprovals = 2
+//	review.CodeOwners = true
+//	contributing.WithReviewProcess(review)
+func WithReviewProcess(review ReviewProcess) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if err := review.Valid(); err != nil {
+			return nil, err
+		}
+
+		p.Review = DefaultReview(review)
+
+		return nil, nil
+	}
+}
+
+// WithReleaseProcess adds a release process section to the contribution guidelines.
+//
+// Example:
+//
+//	contributing.WithReleaseProcess(contributing.DefaultReleaseProcess())
+func WithReleaseProcess(release ReleaseProcess) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		if err := release.Valid(); err != nil {
+			return nil, err
+		}
+
+		p.Release = DefaultRelease(release)
+
+		return nil, nil
+	}
+}
+
+// DefaultReview returns the code review section for the review process.
+func DefaultReview(review ReviewProcess) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Code review", func(s *doyoucompute.Section) error {
+		s.WriteIntro().
+			Text("Every pull request is reviewed before it is merged.")
+
+		steps := s.CreateList(doyoucompute.BULLET)
+
+		switch review.RequiredApprovals {
+		case 0:
+			steps.Append("Approving reviews are encouraged but not required to merge")
+		case 1:
+			steps.Append("Pull requests need one approving review before they can be merged")
+		default:
+			steps.Append(fmt.Sprintf("Pull requests need %d approving reviews before they can be merged", review.RequiredApprovals))
+		}
+
+		if review.CodeOwners {
+			steps.Append("Reviewers are requested automatically from the CODEOWNERS file")
+		}
+
+		if review.Turnaround != "" {
+			steps.Append(review.Turnaround)
+		}
+
+		if review.ReRequest != "" {
+			steps.Append(review.ReRequest)
+		}
+
+		return nil
+	})
+
+	return section
+}
+
+// DefaultRelease returns the release process section.
+func DefaultRelease(release ReleaseProcess) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Release process", func(s *doyoucompute.Section) error {
+		versioning := s.WriteParagraph().Text("Releases follow")
+		if release.VersioningUrl != "" {
+			versioning.Link(release.Versioning, release.VersioningUrl)
+		} else {
+			versioning.Text(release.Versioning)
+		}
+
+		if release.Releasers == "" && release.Changelog == "" {
+			return nil
+		}
+
+		steps := s.CreateList(doyoucompute.BULLET)
+
+		if release.Releasers != "" {
+			steps.Append(release.Releasers)
+		}
+
+		if release.Changelog != "" {
+			steps.Append(release.Changelog)
+		}
+
+		return nil
+	})
+
+	return section
+}
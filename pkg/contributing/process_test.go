@@ -0,0 +1,137 @@
+package contributing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+func TestProcessRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "no process sections by default",
+			opts: nil,
+			wantNotContains: []string{
+				"### Code review",
+				"### Release process",
+			},
+		},
+		{
+			name: "default review process",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithReviewProcess(DefaultReviewProcess()),
+			},
+			wantContains: []string{
+				"### Code review",
+				"Pull requests need one approving review before they can be merged",
+				"within a few business days",
+				"re-request review",
+			},
+			wantNotContains: []string{
+				"CODEOWNERS",
+				"### Release process",
+			},
+		},
+		{
+			name: "review with code owners and several approvals",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithReviewProcess(ReviewProcess{RequiredApprovals: 2, CodeOwners: true}),
+			},
+			wantContains: []string{
+				"Pull requests need 2 approving reviews before they can be merged",
+				"Reviewers are requested automatically from the CODEOWNERS file",
+			},
+		},
+		{
+			name: "review without required approvals",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithReviewProcess(ReviewProcess{}),
+			},
+			wantContains: []string{
+				"Approving reviews are encouraged but not required to merge",
+			},
+		},
+		{
+			name: "default release process",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithReleaseProcess(DefaultReleaseProcess()),
+			},
+			wantContains: []string{
+				"### Release process",
+				"Releases follow [Semantic Versioning](https://semver.org)",
+				"Maintainers cut releases by tagging the main branch",
+				"release notes",
+			},
+		},
+		{
+			name: "release process without url",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithReleaseProcess(ReleaseProcess{Versioning: "Calendar Versioning"}),
+			},
+			wantContains: []string{
+				"Releases follow Calendar Versioning",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New("https://github.com/user/project", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    doyoucompute.OptionBuilder[ContributingProps]
+		errMsg string
+	}{
+		{
+			name:   "negative approvals",
+			opt:    WithReviewProcess(ReviewProcess{RequiredApprovals: -1}),
+			errMsg: "required approvals cannot be negative",
+		},
+		{
+			name:   "release without versioning",
+			opt:    WithReleaseProcess(ReleaseProcess{Releasers: "Maintainers"}),
+			errMsg: "versioning scheme cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("https://github.com/user/project", "", tt.opt)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("New() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package contributing
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Profile describes the commands contributors run for a language ecosystem.
+// Profiles drive the install, test and lint steps of the setup and development sections.
+type Profile struct {
+	// Name is the display name of the ecosystem, e.g. "Go"
+	Name string
+	// Install contains the commands that install dependencies
+	Install []string
+	// Test contains the commands that run the test suite
+	Test []string
+	// Lint contains the commands that run linters and formatters
+	Lint []string
+}
+
+// Valid returns an error if the profile cannot be rendered.
+func (p Profile) Valid() error {
+	if p.Name == "" {
+		return errors.New("profile name cannot be empty")
+	}
+	if len(p.Test) == 0 {
+		return fmt.Errorf("profile %s must include at least one test command", p.Name)
+	}
+
+	return nil
+}
+
+// GoProfile returns the profile for Go modules, the default profile.
+// It has no lint commands, so the default guide is unchanged; set Lint on a copy
+// and pass it to WithProfile to ask contributors to run linters.
+func GoProfile() Profile {
+	return Profile{
+		Name:    "Go",
+		Install: []string{"go mod tidy"},
+		Test:    []string{"go test ./..."},
+	}
+}
+
+// PythonUvProfile returns the profile for Python projects managed with uv.
+func PythonUvProfile() Profile {
+	return Profile{
+		Name:    "Python (uv)",
+		Install: []string{"uv sync"},
+		Test:    []string{"uv run pytest"},
+		Lint:    []string{"uv run ruff check ."},
+	}
+}
+
+// PythonPipProfile returns the profile for Python projects installed with pip.
+func PythonPipProfile() Profile {
+	return Profile{
+		Name:    "Python (pip)",
+		Install: []string{"python -m venv .venv", "source .venv/bin/activate", "pip install -e ."},
+		Test:    []string{"pytest"},
+		Lint:    []string{"ruff check ."},
+	}
+}
+
+// NodeNpmProfile returns the profile for Node.js projects managed with npm.
+func NodeNpmProfile() Profile {
+	return Profile{
+		Name:    "Node.js (npm)",
+		Install: []string{"npm install"},
+		Test:    []string{"npm test"},
+		Lint:    []string{"npm run lint"},
+	}
+}
+
+// NodePnpmProfile returns the profile for Node.js projects managed with pnpm.
+func NodePnpmProfile() Profile {
+	return Profile{
+		Name:    "Node.js (pnpm)",
+		Install: []string{"pnpm install"},
+		Test:    []string{"pnpm test"},
+		Lint:    []string{"pnpm lint"},
+	}
+}
+
+// RustProfile returns the profile for Rust crates built with cargo.
+func RustProfile() Profile {
+	return Profile{
+		Name:    "Rust",
+		Install: []string{"cargo build"},
+		Test:    []string{"cargo test"},
+		Lint:    []string{"cargo fmt --check", "cargo clippy -- -D warnings"},
+	}
+}
+
+type profileDetector struct {
+	manifest string
+	detect   func(fsys fs.FS) Profile
+}
+
+// Checked in order, so a Go module with a package.json for tooling is still a Go project
+var profileDetectors = []profileDetector{
+	{manifest: "go.mod", detect: func(fs.FS) Profile { return GoProfile() }},
+	{manifest: "Cargo.toml", detect: func(fs.FS) Profile { return RustProfile() }},
+	{manifest: "pyproject.toml", detect: func(fsys fs.FS) Profile {
+		if exists(fsys, "uv.lock") {
+			return PythonUvProfile()
+		}
+		return PythonPipProfile()
+	}},
+	{manifest: "requirements.txt", detect: func(fs.FS) Profile {
+		profile := PythonPipProfile()
+		profile.Install = []string{"python -m venv .venv", "source .venv/bin/activate", "pip install -r requirements.txt"}
+		return profile
+	}},
+	{manifest: "package.json", detect: func(fsys fs.FS) Profile {
+		if exists(fsys, "pnpm-lock.yaml") {
+			return NodePnpmProfile()
+		}
+		return NodeNpmProfile()
+	}},
+}
+
+// DetectProfile picks a profile from the manifest files at the root of fsys.
+// Returns an error if no supported manifest is found.
+//
+// Example:
+//
+//	profile, err := contributing.DetectProfile(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	contributing.WithProfile(profile)
+func DetectProfile(fsys fs.FS) (Profile, error) {
+	manifests := make([]string, len(profileDetectors))
+
+	for idx, detector := range profileDetectors {
+		if exists(fsys, detector.manifest) {
+			return detector.detect(fsys), nil
+		}
+
+		manifests[idx] = detector.manifest
+	}
+
+	return Profile{}, fmt.Errorf("no supported project manifest found, looked for %s", strings.Join(manifests, ", "))
+}
+
+func exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+
+	return err == nil
+}
@@ -0,0 +1,208 @@
+package contributing
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "go module",
+			fsys:     fstest.MapFS{"go.mod": {}},
+			wantName: "Go",
+		},
+		{
+			name:     "go module wins over package.json",
+			fsys:     fstest.MapFS{"go.mod": {}, "package.json": {}},
+			wantName: "Go",
+		},
+		{
+			name:     "rust crate",
+			fsys:     fstest.MapFS{"Cargo.toml": {}},
+			wantName: "Rust",
+		},
+		{
+			name:     "python with uv lockfile",
+			fsys:     fstest.MapFS{"pyproject.toml": {}, "uv.lock": {}},
+			wantName: "Python (uv)",
+		},
+		{
+			name:     "python without uv lockfile",
+			fsys:     fstest.MapFS{"pyproject.toml": {}},
+			wantName: "Python (pip)",
+		},
+		{
+			name:     "python requirements file",
+			fsys:     fstest.MapFS{"requirements.txt": {}},
+			wantName: "Python (pip)",
+		},
+		{
+			name:     "node with pnpm lockfile",
+			fsys:     fstest.MapFS{"package.json": {}, "pnpm-lock.yaml": {}},
+			wantName: "Node.js (pnpm)",
+		},
+		{
+			name:     "node without pnpm lockfile",
+			fsys:     fstest.MapFS{"package.json": {}},
+			wantName: "Node.js (npm)",
+		},
+		{
+			name:    "no manifest",
+			fsys:    fstest.MapFS{"README.md": {}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := DetectProfile(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if profile.Name != tt.wantName {
+				t.Errorf("DetectProfile() name = %v, want %v", profile.Name, tt.wantName)
+			}
+			if err := profile.Valid(); err != nil {
+				t.Errorf("DetectProfile() returned invalid profile: %v", err)
+			}
+		})
+	}
+}
+
+func TestProfileRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		profile         Profile
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:    "rust",
+			profile: RustProfile(),
+			wantContains: []string{
+				"cargo build",
+				"cargo test",
+				"cargo clippy -- -D warnings",
+			},
+			wantNotContains: []string{
+				"go test ./...",
+				"go mod tidy",
+			},
+		},
+		{
+			name:    "pnpm",
+			profile: NodePnpmProfile(),
+			wantContains: []string{
+				"pnpm install",
+				"pnpm test",
+				"pnpm lint",
+			},
+		},
+		{
+			name:    "uv",
+			profile: PythonUvProfile(),
+			wantContains: []string{
+				"uv sync",
+				"uv run pytest",
+				"uv run ruff check .",
+			},
+		},
+		{
+			name:    "default go profile has no lint step",
+			profile: GoProfile(),
+			wantContains: []string{
+				"go mod tidy",
+				"go test ./...",
+			},
+			wantNotContains: []string{
+				"Run the linters before committing",
+			},
+		},
+		{
+			name: "custom profile without lint",
+			profile: Profile{
+				Name: "Make",
+				Test: []string{"make test"},
+			},
+			wantContains: []string{
+				"make test",
+			},
+			wantNotContains: []string{
+				"Run the linters before committing",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(
+				"https://github.com/user/project",
+				"",
+				WithProfile(tt.profile),
+			)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		errMsg  string
+	}{
+		{
+			name:    "missing name",
+			profile: Profile{Test: []string{"make test"}},
+			errMsg:  "profile name cannot be empty",
+		},
+		{
+			name:    "missing test command",
+			profile: Profile{Name: "Make"},
+			errMsg:  "at least one test command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("https://github.com/user/project", "", WithProfile(tt.profile))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("New() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
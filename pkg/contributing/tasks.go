@@ -0,0 +1,85 @@
+package contributing
+
+import (
+	"fmt"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+// TaskLink is a label linked to the issue search that lists its open issues.
+type TaskLink struct {
+	// Label is the label being searched for
+	Label labels.Label
+	// Url lists the open issues with the label
+	Url string
+}
+
+// DefaultTaskLabels returns the labels commonly used to mark issues for new contributors.
+// No labels are linked unless they are passed to WithTaskLabels.
+func DefaultTaskLabels() []labels.Label {
+	return []labels.Label{labels.GoodFirstIssue(), labels.HelpWanted()}
+}
+
+// TaskLinks builds the issue search links for the labels on the repository's forge.
+// Returns an error if the forge does not support searching issues by label.
+func TaskLinks(repository repourl.URL, taskLabels []labels.Label) ([]TaskLink, error) {
+	links := make([]TaskLink, 0, len(taskLabels))
+
+	for _, label := range taskLabels {
+		if err := label.Valid(); err != nil {
+			return nil, err
+		}
+
+		url, err := repository.LabelSearchURL(label.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		links = append(links, TaskLink{Label: label, Url: url})
+	}
+
+	return links, nil
+}
+
+// issueTrackerTaskLinks builds the issue search links for the repository behind the issue tracker url.
+func issueTrackerTaskLinks(issueTrackerUrl string, taskLabels []labels.Label) ([]TaskLink, error) {
+	if len(taskLabels) == 0 {
+		return nil, nil
+	}
+
+	repository, err := repourl.Parse(issueTrackerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("issue tracker %q is not a repository url: %w", issueTrackerUrl, err)
+	}
+
+	return TaskLinks(repository, taskLabels)
+}
+
+// WithTaskLabels sets the labels linked from the task selection section.
+// Labels are searched on the issue tracker's forge, which must be GitHub or GitLab.
+// Passing no labels removes the links, as when the option is left out.
+//
+// Example:
+//
+//	taskLabels, err := labels.Default().Lookup(labels.GOOD_FIRST_ISSUE, labels.DOCUMENTATION)
+//	if err != nil {
+//		// handle error
+//	}
+//	contributing.WithTaskLabels(taskLabels...)
+func WithTaskLabels(taskLabels ...labels.Label) doyoucompute.OptionBuilder[ContributingProps] {
+	return func(p *ContributingProps) (doyoucompute.Finalizer[ContributingProps], error) {
+		return func(p *ContributingProps) error {
+			links, err := issueTrackerTaskLinks(p.IssueTrackerUrl, taskLabels)
+			if err != nil {
+				return fmt.Errorf("could not link task labels: %w", err)
+			}
+
+			p.TaskLabels = taskLabels
+			p.ChoseATask = DefaultChoseATask(p.IssueTrackerUrl, links...)
+
+			return nil
+		}, nil
+	}
+}
@@ -0,0 +1,153 @@
+package contributing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+)
+
+func TestTaskLabelRendering(t *testing.T) {
+	tests := []struct {
+		name            string
+		projectUrl      string
+		issueTrackerUrl string
+		opts            []doyoucompute.OptionBuilder[ContributingProps]
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:            "no label links by default",
+			projectUrl:      "https://github.com/user/project",
+			wantNotContains: []string{"New to the project?"},
+		},
+		{
+			name:       "github links default labels",
+			projectUrl: "https://github.com/user/project",
+			opts:       []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(DefaultTaskLabels()...)},
+			wantContains: []string{
+				"[good first issue](https://github.com/user/project/issues?q=is%3Aissue+is%3Aopen+label%3A%22good+first+issue%22) - Good for newcomers",
+				"[help wanted](https://github.com/user/project/issues?q=is%3Aissue+is%3Aopen+label%3A%22help+wanted%22)",
+			},
+		},
+		{
+			name:       "gitlab links default labels",
+			projectUrl: "https://gitlab.com/group/sub/project",
+			opts:       []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(DefaultTaskLabels()...)},
+			wantContains: []string{
+				"[good first issue](https://gitlab.com/group/sub/project/-/issues?state=opened&label_name%5B%5D=good+first+issue)",
+			},
+		},
+		{
+			name:            "legacy gitlab issue tracker",
+			projectUrl:      "https://gitlab.com/group/project",
+			issueTrackerUrl: "https://gitlab.com/group/project/issues",
+			opts:            []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(labels.GoodFirstIssue())},
+			wantContains:    []string{"https://gitlab.com/group/project/-/issues?state=opened&label_name%5B%5D=good+first+issue"},
+		},
+		{
+			name:            "bitbucket has no label links",
+			projectUrl:      "https://bitbucket.org/team/project",
+			wantNotContains: []string{"New to the project?"},
+		},
+		{
+			name:            "external issue tracker has no label links",
+			projectUrl:      "https://github.com/user/project",
+			issueTrackerUrl: "https://jira.example.com/projects/PROJ",
+			wantNotContains: []string{"New to the project?"},
+		},
+		{
+			name:            "links follow issue tracker override",
+			projectUrl:      "https://github.com/user/project",
+			opts:            []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(DefaultTaskLabels()...), WithIssueTrackerUrl("https://github.com/user/tracker/issues")},
+			wantContains:    []string{"https://github.com/user/tracker/issues?q="},
+			wantNotContains: []string{"https://github.com/user/project/issues?q="},
+		},
+		{
+			name:       "custom labels",
+			projectUrl: "https://github.com/user/project",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTaskLabels(labels.Documentation()),
+			},
+			wantContains:    []string{"[documentation](https://github.com/user/project/issues?q=is%3Aissue+is%3Aopen+label%3A%22documentation%22)"},
+			wantNotContains: []string{"good+first+issue"},
+		},
+		{
+			name:       "no labels removes links",
+			projectUrl: "https://github.com/user/project",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTaskLabels(DefaultTaskLabels()...),
+				WithTaskLabels(),
+			},
+			wantNotContains: []string{"New to the project?"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.projectUrl, tt.issueTrackerUrl, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() missing expected content: %q", want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskLabelValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		projectUrl string
+		opts       []doyoucompute.OptionBuilder[ContributingProps]
+		errMsg     string
+	}{
+		{
+			name:       "unsupported forge",
+			projectUrl: "https://bitbucket.org/team/project",
+			opts:       []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(labels.GoodFirstIssue())},
+			errMsg:     "label search is not supported for Bitbucket",
+		},
+		{
+			name:       "invalid label",
+			projectUrl: "https://github.com/user/project",
+			opts:       []doyoucompute.OptionBuilder[ContributingProps]{WithTaskLabels(labels.Label{})},
+			errMsg:     "label name cannot be empty",
+		},
+		{
+			name:       "issue tracker override without label search",
+			projectUrl: "https://github.com/user/project",
+			opts: []doyoucompute.OptionBuilder[ContributingProps]{
+				WithTaskLabels(labels.GoodFirstIssue()),
+				WithIssueTrackerUrl("https://jira.example.com/projects/PROJ"),
+			},
+			errMsg: "could not link task labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.projectUrl, "", tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("New() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
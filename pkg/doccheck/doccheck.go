@@ -0,0 +1,566 @@
+// Package doccheck verifies the content of generated documents.
+//
+// Relative links are resolved against the repository tree and the other
+// generated documents, including heading anchors, so moved files are caught
+// before the rendered docs are published.
+//
+// Go code blocks are type-checked against the module they are documented in,
+// so samples that drift from the API fail in CI instead of in a reader's editor.
+// Blocks may be complete files, top-level declarations, or statements; fragments
+// are wrapped in a synthetic package and function, and imports for packages they
+// reference by name are added automatically.
+//
+// Dependencies are loaded from source through go/build, so checks need the
+// module's dependencies in the local module cache but no network access.
+//
+// Basic usage:
+//
+//	checker, err := doccheck.NewChecker(".")
+//	if err != nil {
+//		// handle error
+//	}
+//	findings, err := checker.Check("README.md", doc)
+//	if err != nil {
+//		// handle error
+//	}
+//	for _, finding := range findings {
+//		fmt.Println(finding)
+//	}
+package doccheck
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// GO_BLOCK_TYPES are the code block types checked as Go source.
+var GO_BLOCK_TYPES = []string{"go", "golang"}
+
+// STD_PACKAGES are the standard library packages imported automatically when a
+// fragment references them by name without importing them.
+var STD_PACKAGES = []string{
+	"bytes",
+	"context",
+	"embed",
+	"errors",
+	"fmt",
+	"io",
+	"io/fs",
+	"log",
+	"net/http",
+	"net/url",
+	"os",
+	"path",
+	"path/filepath",
+	"regexp",
+	"sort",
+	"strconv",
+	"strings",
+	"testing",
+	"testing/fstest",
+	"time",
+}
+
+// Severity is how serious a finding is.
+type Severity int
+
+const (
+	// Error findings are problems that break the document
+	Error Severity = iota
+	// Warning findings could not be verified and may need a manual check
+	Warning
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	}
+
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Finding is a problem found in a document.
+type Finding struct {
+	// Document is the name the document was checked under, usually its output path
+	Document string
+	// Section is the path of section headings containing the problem, outermost first
+	Section []string
+	// Line is the line of the problem in the rendered markdown, starting at 1
+	Line int
+	// Severity is Error unless the problem could not be verified
+	Severity Severity
+	// Message describes the problem
+	Message string
+}
+
+// String formats the finding as "document:line: section > path: message".
+// Warnings are prefixed with "warning: " before the message.
+func (f Finding) String() string {
+	location := fmt.Sprintf("%s:%d", f.Document, f.Line)
+
+	message := f.Message
+	if f.Severity != Error {
+		message = fmt.Sprintf("%s: %s", f.Severity, f.Message)
+	}
+
+	if len(f.Section) == 0 {
+		return fmt.Sprintf("%s: %s", location, message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", location, strings.Join(f.Section, " > "), message)
+}
+
+// Checker type-checks Go code blocks against a module.
+// A Checker caches the packages it loads, so reuse it across documents.
+type Checker struct {
+	dir      string
+	fset     *token.FileSet
+	importer types.Importer
+	imports  map[string]string
+	checked  int
+}
+
+// NewChecker returns a Checker for the module rooted at dir.
+// The packages of the module, its direct requirements and common standard library
+// packages can be referenced by fragments without an import.
+func NewChecker(dir string) (*Checker, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve module directory: %w", err)
+	}
+
+	module, requires, err := readGoMod(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	checker := &Checker{
+		dir:      dir,
+		fset:     fset,
+		importer: importer.ForCompiler(fset, "source", nil),
+		imports:  map[string]string{},
+	}
+
+	for _, importPath := range STD_PACKAGES {
+		checker.AddImport(path.Base(importPath), importPath)
+	}
+
+	for _, importPath := range requires {
+		checker.AddImport(guessPackageName(importPath), importPath)
+	}
+
+	packages, err := modulePackages(dir, module)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, importPath := range packages {
+		checker.AddImport(name, importPath)
+	}
+
+	return checker, nil
+}
+
+// AddImport registers the package imported automatically when a fragment references name.
+// Packages registered later replace earlier ones with the same name.
+//
+// Example:
+//
+//	checker.AddImport("app", "github.com/MoonMoon1919/doyoucompute/pkg/app")
+func (c *Checker) AddImport(name, importPath string) {
+	c.imports[name] = importPath
+}
+
+// Check type-checks the Go code blocks in doc and returns a finding for each error.
+// The name identifies the document in findings, e.g. "README.md".
+// Unused variables and imports are not reported, since samples often declare values
+// only to show how they are built.
+func (c *Checker) Check(name string, doc doyoucompute.Document) ([]Finding, error) {
+	rendered, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("could not render %s: %w", name, err)
+	}
+
+	var findings []Finding
+	offset := 0
+
+	for _, block := range goBlocks(doc) {
+		fence := "```" + block.blockType + "\n" + block.code + "\n```"
+
+		idx := strings.Index(rendered[offset:], fence)
+		if idx == -1 {
+			return nil, fmt.Errorf("could not find code block from section %q in rendered %s", strings.Join(block.section, " > "), name)
+		}
+
+		// The code starts on the line after the opening fence
+		start := strings.Count(rendered[:offset+idx], "\n") + 2
+		offset += idx + len(fence)
+
+		for _, problem := range c.CheckCode(block.code) {
+			findings = append(findings, Finding{
+				Document: name,
+				Section:  block.section,
+				Line:     start + problem.Line - 1,
+				Message:  problem.Message,
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// Problem is an error in a piece of Go code.
+type Problem struct {
+	// Line is the line of the error in the code, starting at 1
+	Line int
+	// Message describes the error
+	Message string
+}
+
+// CheckCode type-checks a single piece of Go code and returns its errors.
+// Complete files are checked as they are. Top-level declarations are placed in a
+// package, and statements in the body of a function in that package.
+func (c *Checker) CheckCode(code string) []Problem {
+	c.checked++
+	filename := filepath.Join(c.dir, fmt.Sprintf("doccheck_%d.go", c.checked))
+
+	file, header, err := c.parse(filename, code)
+	if err != nil {
+		return syntaxProblems(err, header)
+	}
+
+	if header > 0 {
+		if imports := c.missingImports(file); len(imports) > 0 {
+			file, header, err = c.parse(filename, code, imports...)
+			if err != nil {
+				return syntaxProblems(err, header)
+			}
+		}
+	}
+
+	var problems []Problem
+
+	config := types.Config{
+		Importer: c.importer,
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) && typeErr.Soft {
+				return
+			}
+
+			problems = append(problems, problem(err, header))
+		},
+	}
+
+	config.Check(file.Name.Name, c.fset, []*ast.File{file}, nil)
+
+	return problems
+}
+
+// parse parses code as a complete file, then as top-level declarations, then as statements.
+// Fragments are wrapped with a single header line, so the returned header is the number
+// of lines added before the code.
+func (c *Checker) parse(filename, code string, imports ...string) (*ast.File, int, error) {
+	if len(imports) == 0 {
+		if file, err := parser.ParseFile(c.fset, filename, code, parser.SkipObjectResolution); err == nil {
+			return file, 0, nil
+		}
+	}
+
+	header := "package main;"
+	for _, importPath := range imports {
+		header += fmt.Sprintf(" import %q;", importPath)
+	}
+
+	if file, err := parser.ParseFile(c.fset, filename, header+"\n"+code, parser.SkipObjectResolution); err == nil {
+		return file, 1, nil
+	}
+
+	file, err := parser.ParseFile(c.fset, filename, header+" func _() {\n"+code+"\n}", parser.SkipObjectResolution)
+
+	return file, 1, err
+}
+
+// missingImports returns the import paths of registered packages the file references
+// as the operand of a selector without declaring or importing them.
+func (c *Checker) missingImports(file *ast.File) []string {
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+	config := types.Config{Importer: c.importer, Error: func(error) {}}
+	config.Check(file.Name.Name, c.fset, []*ast.File{file}, info)
+
+	seen := map[string]bool{}
+	var imports []string
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := selector.X.(*ast.Ident)
+		if !ok || info.Uses[ident] != nil {
+			return true
+		}
+
+		if importPath, ok := c.imports[ident.Name]; ok && !seen[importPath] {
+			seen[importPath] = true
+			imports = append(imports, importPath)
+		}
+
+		return true
+	})
+
+	sort.Strings(imports)
+
+	return imports
+}
+
+func problem(err error, header int) Problem {
+	var typeErr types.Error
+	if errors.As(err, &typeErr) {
+		return Problem{
+			Line:    typeErr.Fset.Position(typeErr.Pos).Line - header,
+			Message: typeErr.Msg,
+		}
+	}
+
+	return Problem{Message: err.Error()}
+}
+
+func syntaxProblems(err error, header int) []Problem {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []Problem{{Message: err.Error()}}
+	}
+
+	problems := make([]Problem, 0, len(list))
+	for _, syntaxErr := range list {
+		problems = append(problems, Problem{
+			Line:    max(syntaxErr.Pos.Line-header, 1),
+			Message: syntaxErr.Msg,
+		})
+	}
+
+	return problems
+}
+
+// goBlock is a Go code block and the sections containing it.
+type goBlock struct {
+	section   []string
+	blockType string
+	code      string
+}
+
+// goBlocks returns the Go code blocks of the document in rendering order.
+func goBlocks(doc doyoucompute.Document) []goBlock {
+	var blocks []goBlock
+
+	var walk func(nodes []doyoucompute.Node, section []string)
+	walk = func(nodes []doyoucompute.Node, section []string) {
+		for _, node := range nodes {
+			if structure, ok := node.(doyoucompute.Structurer); ok {
+				path := section
+				if node.Type() == doyoucompute.SectionType {
+					path = append(append([]string{}, section...), structure.Identifier())
+				}
+
+				walk(structure.Children(), path)
+				continue
+			}
+
+			content, ok := node.(doyoucompute.Contenter)
+			if !ok {
+				continue
+			}
+
+			blockType, code, ok := codeBlock(content)
+			if !ok || !isGo(blockType) {
+				continue
+			}
+
+			blocks = append(blocks, goBlock{section: section, blockType: blockType, code: code})
+		}
+	}
+
+	walk(doc.Content, nil)
+
+	return blocks
+}
+
+// codeBlock returns the type and content of static and executable code blocks.
+func codeBlock(content doyoucompute.Contenter) (string, string, bool) {
+	var key string
+
+	switch content.Type() {
+	case doyoucompute.CodeBlockType:
+		key = "BlockType"
+	case doyoucompute.ExecutableType:
+		key = "Shell"
+	default:
+		return "", "", false
+	}
+
+	materialized, err := content.Materialize()
+	if err != nil {
+		return "", "", false
+	}
+
+	blockType, ok := materialized.Metadata[key].(string)
+
+	return blockType, materialized.Content, ok
+}
+
+func isGo(blockType string) bool {
+	for _, goType := range GO_BLOCK_TYPES {
+		if strings.EqualFold(blockType, goType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readGoMod returns the module path and the required module paths from a go.mod file.
+func readGoMod(name string) (string, []string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not read go.mod: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		module    string
+		requires  []string
+		inRequire bool
+	)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			requires = append(requires, fields[0])
+		case fields[0] == "module" && len(fields) > 1:
+			module = strings.Trim(fields[1], `"`)
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequire = true
+		case fields[0] == "require" && len(fields) > 1:
+			requires = append(requires, fields[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", nil, fmt.Errorf("could not read go.mod: %w", err)
+	}
+
+	if module == "" {
+		return "", nil, fmt.Errorf("go.mod in %s has no module directive", filepath.Dir(name))
+	}
+
+	return module, requires, nil
+}
+
+// guessPackageName returns the conventional package name for an import path,
+// the last element without a major version suffix.
+func guessPackageName(importPath string) string {
+	base := path.Base(importPath)
+
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(importPath))
+	}
+
+	return base
+}
+
+// modulePackages returns the import paths of the module's non-main packages by package name.
+func modulePackages(dir, module string) (map[string]string, error) {
+	packages := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			base := entry.Name()
+			if name != dir && (strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_") || base == "testdata" || base == "vendor") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(name))
+		if err != nil {
+			return err
+		}
+
+		importPath := module
+		if rel != "." {
+			importPath = path.Join(module, filepath.ToSlash(rel))
+		}
+
+		if _, ok := packages[importPath]; ok {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return nil
+		}
+
+		packages[importPath] = file.Name.Name
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list module packages: %w", err)
+	}
+
+	importPaths := make([]string, 0, len(packages))
+	for importPath := range packages {
+		importPaths = append(importPaths, importPath)
+	}
+
+	// Sorted so the shortest import path wins when package names collide
+	sort.Sort(sort.Reverse(sort.StringSlice(importPaths)))
+
+	byName := map[string]string{}
+	for _, importPath := range importPaths {
+		if name := packages[importPath]; name != "main" {
+			byName[name] = importPath
+		}
+	}
+
+	return byName, nil
+}
@@ -0,0 +1,155 @@
+package doccheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// newChecker returns a checker for this repository's module.
+func newChecker(t *testing.T) *Checker {
+	t.Helper()
+
+	checker, err := NewChecker("../..")
+	if err != nil {
+		t.Fatalf("NewChecker() error = %v", err)
+	}
+
+	return checker
+}
+
+func TestCheckCode(t *testing.T) {
+	checker := newChecker(t)
+
+	tests := []struct {
+		name string
+		code string
+		want []Problem
+	}{
+		{
+			name: "statements with automatic imports",
+			code: strings.Join([]string{
+				`doc, err := bugreport.New(bugreport.WithName("Crash report"))`,
+				`if err != nil {`,
+				`	panic(err)`,
+				`}`,
+				`fmt.Println(doc.Name)`,
+			}, "\n"),
+		},
+		{
+			name: "unused variables are allowed",
+			code: `doc, err := pullrequest.New()`,
+		},
+		{
+			name: "top-level declarations",
+			code: strings.Join([]string{
+				`func build() (doyoucompute.Document, error) {`,
+				`	return bugreport.New()`,
+				`}`,
+			}, "\n"),
+		},
+		{
+			name: "complete file",
+			code: strings.Join([]string{
+				`package example`,
+				``,
+				`import "strings"`,
+				``,
+				`var upper = strings.ToUpper("x")`,
+			}, "\n"),
+		},
+		{
+			name: "complete files are not given imports",
+			code: strings.Join([]string{
+				`package example`,
+				``,
+				`var upper = strings.ToUpper("x")`,
+			}, "\n"),
+			want: []Problem{{Line: 3, Message: "undefined: strings"}},
+		},
+		{
+			name: "type errors are reported on the line in the block",
+			code: strings.Join([]string{
+				`doc, err := bugreport.New(`,
+				`	bugreport.WithName(42),`,
+				`)`,
+			}, "\n"),
+			want: []Problem{{Line: 2, Message: "cannot use 42 (untyped int constant) as string value in argument to bugreport.WithName"}},
+		},
+		{
+			name: "undefined identifiers",
+			code: `doc, err := bugreport.NewReport()`,
+			want: []Problem{{Line: 1, Message: "undefined: bugreport.NewReport"}},
+		},
+		{
+			name: "syntax errors",
+			code: strings.Join([]string{
+				`fmt.Println("ok")`,
+				`if {`,
+				`}`,
+			}, "\n"),
+			want: []Problem{{Line: 2, Message: "missing condition in if statement"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checker.CheckCode(tt.code)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("CheckCode() = %v, want %v", got, tt.want)
+			}
+
+			for idx := range tt.want {
+				if got[idx].Line != tt.want[idx].Line || !strings.Contains(got[idx].Message, tt.want[idx].Message) {
+					t.Errorf("CheckCode()[%d] = %v, want %v", idx, got[idx], tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	checker := newChecker(t)
+
+	doc, err := doyoucompute.DocumentFactory("Samples", func(d *doyoucompute.Document) error {
+		d.WriteIntro().Text("Samples of the templates.")
+
+		usage := d.CreateSection("Usage")
+		usage.WriteCodeBlock("bash", []string{"go get example.com/not-go"}, doyoucompute.Static)
+
+		basics := usage.CreateSection("Basics")
+		basics.WriteParagraph().Text("Create a bug report:")
+		basics.WriteCodeBlock("go", []string{"doc, err := bugreport.New()"}, doyoucompute.Static)
+		basics.WriteCodeBlock("go", []string{"doc, err := bugreport.New()\nfmt.Println(doc.Title)"}, doyoucompute.Static)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DocumentFactory() error = %v", err)
+	}
+
+	findings, err := checker.Check("SAMPLES.md", doc)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if len(findings) != 1 {
+		t.Fatalf("Check() = %v, want a single finding", findings)
+	}
+
+	rendered, _ := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	lines := strings.Split(rendered, "\n")
+
+	finding := findings[0]
+	if lines[finding.Line-1] != "fmt.Println(doc.Title)" {
+		t.Errorf("Check() line %d = %q, want the line with the error", finding.Line, lines[finding.Line-1])
+	}
+
+	want := fmt.Sprintf("SAMPLES.md:%d: Usage > Basics: doc.Title undefined", finding.Line)
+	if !strings.HasPrefix(finding.String(), want) {
+		t.Errorf("Finding.String() = %q, should start with %q", finding.String(), want)
+	}
+}
@@ -0,0 +1,261 @@
+package doccheck
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/registry"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/toc"
+)
+
+var (
+	// linkPattern matches the target of inline links and images, e.g. "[text](target)"
+	linkPattern = regexp.MustCompile(`\]\(([^()\s]+)\)`)
+	// codeSpanPattern matches inline code, which may contain link-like text
+	codeSpanPattern = regexp.MustCompile("`[^`]*`")
+	// commentPattern matches single-line HTML comments
+	commentPattern = regexp.MustCompile(`<!--.*?-->`)
+)
+
+// CheckLinks renders each entry and checks its links against the repository in fsys.
+// Relative links must point to a file or directory in fsys or to another entry,
+// and heading anchors must exist in the target document. Paths starting with "/"
+// are resolved from the repository root.
+//
+// Absolute URLs are reported as warnings without being fetched, so the check runs offline.
+// Links inside code blocks, inline code and HTML comments are ignored.
+//
+// Example:
+//
+//	findings, err := doccheck.CheckLinks(os.DirFS("."), docs.Entries()...)
+func CheckLinks(fsys fs.FS, entries ...registry.Entry) ([]Finding, error) {
+	rendered := map[string]string{}
+
+	for _, entry := range entries {
+		markdown, err := doyoucompute.NewMarkdownRenderer().Render(&entry.Document)
+		if err != nil {
+			return nil, fmt.Errorf("could not render %s: %w", entry.Path, err)
+		}
+
+		rendered[path.Clean(entry.Path)] = markdown
+	}
+
+	resolver := linkResolver{fsys: fsys, rendered: rendered, anchors: map[string]map[string]bool{}}
+
+	var findings []Finding
+
+	for _, entry := range entries {
+		source := path.Clean(entry.Path)
+
+		for _, link := range scanLinks(rendered[source]) {
+			severity, message := resolver.resolve(source, link.target)
+			if message == "" {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Document: entry.Path,
+				Section:  link.section,
+				Line:     link.line,
+				Severity: severity,
+				Message:  message,
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// link is a link target found in rendered markdown.
+type link struct {
+	target  string
+	line    int
+	section []string
+}
+
+// scanLinks returns the links in markdown with the line and section headings they appear under.
+// The document title is not part of the section path.
+func scanLinks(markdown string) []link {
+	var (
+		links    []link
+		headings []string
+	)
+
+	for _, line := range scanLines(markdown) {
+		if line.level > 0 {
+			if line.level == 1 {
+				headings = nil
+				continue
+			}
+
+			for len(headings) >= line.level-1 {
+				headings = headings[:len(headings)-1]
+			}
+			headings = append(headings, line.text)
+
+			continue
+		}
+
+		text := commentPattern.ReplaceAllString(codeSpanPattern.ReplaceAllString(line.text, ""), "")
+
+		for _, match := range linkPattern.FindAllStringSubmatch(text, -1) {
+			links = append(links, link{
+				target:  match[1],
+				line:    line.number,
+				section: append([]string{}, headings...),
+			})
+		}
+	}
+
+	return links
+}
+
+// markdownLine is a line of markdown outside code blocks and frontmatter.
+type markdownLine struct {
+	number int
+	// level is the heading level, or 0 for lines that are not headings
+	level int
+	text  string
+}
+
+// scanLines returns the lines of markdown that can contain links or headings.
+func scanLines(markdown string) []markdownLine {
+	var (
+		lines       []markdownLine
+		inFence     bool
+		frontmatter bool
+	)
+
+	for idx, text := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(text)
+
+		switch {
+		case idx == 0 && trimmed == "---":
+			frontmatter = true
+			continue
+		case frontmatter:
+			frontmatter = trimmed != "---"
+			continue
+		case strings.HasPrefix(trimmed, "```"):
+			inFence = !inFence
+			continue
+		case inFence:
+			continue
+		}
+
+		line := markdownLine{number: idx + 1, text: text}
+
+		if hashes := len(trimmed) - len(strings.TrimLeft(trimmed, "#")); hashes > 0 && hashes <= 6 && strings.HasPrefix(trimmed[hashes:], " ") {
+			line.level = hashes
+			line.text = strings.TrimSpace(trimmed[hashes:])
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// headingAnchors returns the anchors GitHub generates for the headings in markdown.
+func headingAnchors(markdown string) map[string]bool {
+	anchors := map[string]bool{}
+	slugger := toc.NewSlugger()
+
+	for _, line := range scanLines(markdown) {
+		if line.level > 0 {
+			anchors[slugger.Slug(line.text)] = true
+		}
+	}
+
+	return anchors
+}
+
+// linkResolver resolves link targets against the repository and the rendered entries.
+type linkResolver struct {
+	fsys     fs.FS
+	rendered map[string]string
+	anchors  map[string]map[string]bool
+}
+
+// resolve returns the severity and a description of the problem with target,
+// or an empty message when the link resolves.
+func (r linkResolver) resolve(source, target string) (Severity, string) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return Error, fmt.Sprintf("invalid link %q: %v", target, err)
+	}
+
+	if parsed.Scheme != "" || parsed.Host != "" {
+		return Warning, fmt.Sprintf("absolute URL %s was not checked", target)
+	}
+
+	destination := source
+	if parsed.Path != "" {
+		if strings.HasPrefix(parsed.Path, "/") {
+			destination = path.Clean(strings.TrimPrefix(parsed.Path, "/"))
+		} else {
+			destination = path.Join(path.Dir(source), parsed.Path)
+		}
+
+		if destination == ".." || strings.HasPrefix(destination, "../") {
+			return Error, fmt.Sprintf("broken link %q: points outside the repository", target)
+		}
+
+		if _, ok := r.rendered[destination]; !ok {
+			if _, err := fs.Stat(r.fsys, destination); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return Error, fmt.Sprintf("broken link %q: %s does not exist", target, destination)
+				}
+
+				return Error, fmt.Sprintf("broken link %q: %v", target, err)
+			}
+		}
+	}
+
+	if parsed.Fragment == "" {
+		return Error, ""
+	}
+
+	anchors, ok := r.anchorsFor(destination)
+	if !ok {
+		return Error, ""
+	}
+
+	if !anchors[parsed.Fragment] {
+		return Error, fmt.Sprintf("broken anchor %q: no heading with that anchor in %s", target, destination)
+	}
+
+	return Error, ""
+}
+
+// anchorsFor returns the heading anchors of a rendered entry or markdown file.
+// Anchors in other files, such as line links into source code, are not checked.
+func (r linkResolver) anchorsFor(name string) (map[string]bool, bool) {
+	if anchors, ok := r.anchors[name]; ok {
+		return anchors, true
+	}
+
+	markdown, ok := r.rendered[name]
+	if !ok {
+		if path.Ext(name) != ".md" {
+			return nil, false
+		}
+
+		data, err := fs.ReadFile(r.fsys, name)
+		if err != nil {
+			return nil, false
+		}
+
+		markdown = string(data)
+	}
+
+	r.anchors[name] = headingAnchors(markdown)
+
+	return r.anchors[name], true
+}
@@ -0,0 +1,132 @@
+package doccheck
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/registry"
+)
+
+func TestCheckLinks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"LICENSE":                  {Data: []byte("MIT")},
+		"pkg/readme/readme.go":     {Data: []byte("package readme")},
+		"docs/guide.md":            {Data: []byte("# Guide\n\n## Install steps\n")},
+		".github/workflows/ci.yml": {Data: []byte("name: ci")},
+	}
+
+	readme, err := doyoucompute.DocumentFactory("Project", func(d *doyoucompute.Document) error {
+		d.WriteIntro().
+			Link("![ci](https://example.com/badge.svg)", "./.github/workflows/ci.yml")
+
+		usage := d.CreateSection("Usage")
+		usage.WriteParagraph().
+			Text("See").
+			Link("the module", "./pkg/readme/readme.go").
+			Text("and").
+			Link("the guide", "./docs/guide.md#install-steps").
+			Text("and").
+			Link("the license", "/LICENSE")
+		usage.WriteParagraph().
+			Code("[not](./a-link.md)").
+			Text("<!-- [hidden](./hidden.md) -->")
+		usage.WriteCodeBlock("markdown", []string{"[example](./example.md)"}, doyoucompute.Static)
+
+		broken := d.CreateSection("Broken")
+		broken.WriteParagraph().Link("moved", "./pkg/old/old.go")
+		broken.CreateSection("Anchors").WriteParagraph().
+			Link("missing", "./CONTRIBUTING.md#missing").
+			Text("and").
+			Link("outside", "../other/README.md").
+			Text("and").
+			Link("guide", "./docs/guide.md#uninstall")
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DocumentFactory() error = %v", err)
+	}
+
+	contributing, err := doyoucompute.DocumentFactory("Contributing", func(d *doyoucompute.Document) error {
+		gettingStarted := d.CreateSection("Getting started")
+		gettingStarted.WriteParagraph().
+			Link("usage", "../README.md#usage").
+			Text("and").
+			Link("this section", "#getting-started")
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DocumentFactory() error = %v", err)
+	}
+
+	findings, err := CheckLinks(fsys,
+		registry.Entry{Path: "README.md", Document: readme},
+		registry.Entry{Path: "docs/CONTRIBUTING.md", Document: contributing},
+	)
+	if err != nil {
+		t.Fatalf("CheckLinks() error = %v", err)
+	}
+
+	want := []string{
+		"README.md:3: warning: absolute URL https://example.com/badge.svg was not checked",
+		`Broken: broken link "./pkg/old/old.go": pkg/old/old.go does not exist`,
+		`Broken > Anchors: broken link "./CONTRIBUTING.md#missing": CONTRIBUTING.md does not exist`,
+		`Broken > Anchors: broken link "../other/README.md": points outside the repository`,
+		`Broken > Anchors: broken anchor "./docs/guide.md#uninstall": no heading with that anchor in docs/guide.md`,
+	}
+
+	got := make([]string, len(findings))
+	for idx, finding := range findings {
+		got[idx] = finding.String()
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("CheckLinks() = %q, want %d findings", got, len(want))
+	}
+
+	for idx := range want {
+		if !strings.Contains(got[idx], want[idx]) {
+			t.Errorf("CheckLinks()[%d] = %q, should contain %q", idx, got[idx], want[idx])
+		}
+	}
+
+	if findings[0].Severity != Warning || findings[1].Severity != Error {
+		t.Errorf("CheckLinks() severities = %v, %v, want warning then error", findings[0].Severity, findings[1].Severity)
+	}
+}
+
+func TestCheckLinksAnchorsInOutputs(t *testing.T) {
+	readme, _ := doyoucompute.DocumentFactory("Project", func(d *doyoucompute.Document) error {
+		d.CreateSection("Usage").WriteParagraph().Link("setup", "./CONTRIBUTING.md#setting-up")
+		d.CreateSection("Usage")
+
+		return nil
+	})
+
+	contributing, _ := doyoucompute.DocumentFactory("Contributing", func(d *doyoucompute.Document) error {
+		d.CreateSection("Setting up").WriteParagraph().
+			Link("second usage", "./README.md#usage-1").
+			Text("and").
+			Link("third usage", "./README.md#usage-2")
+
+		return nil
+	})
+
+	findings, err := CheckLinks(fstest.MapFS{},
+		registry.Entry{Path: "README.md", Document: readme},
+		registry.Entry{Path: "CONTRIBUTING.md", Document: contributing},
+	)
+	if err != nil {
+		t.Fatalf("CheckLinks() error = %v", err)
+	}
+
+	if len(findings) != 1 || !strings.Contains(findings[0].Message, `"./README.md#usage-2"`) {
+		t.Errorf("CheckLinks() = %v, want only the missing third heading reported", findings)
+	}
+	if len(findings) == 1 && (findings[0].Document != "CONTRIBUTING.md" || findings[0].Line != 5) {
+		t.Errorf("CheckLinks() location = %s:%d, want CONTRIBUTING.md:5", findings[0].Document, findings[0].Line)
+	}
+}
@@ -0,0 +1,70 @@
+// Package files reads template inputs through an io/fs.FS.
+//
+// Reading through a file system instead of the working directory lets
+// generators run from any directory and lets inputs come from embed.FS or
+// fstest.MapFS in tests.
+//
+// Basic usage:
+//
+//	//go:embed samples
+//	var samples embed.FS
+//
+//	src, err := files.Read(samples, "samples/basics.go")
+//	if err != nil {
+//		// handle error
+//	}
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+)
+
+// MissingFileError is returned when a file does not exist in the file system it was looked up in.
+type MissingFileError struct {
+	// Path is the path that was looked up
+	Path string
+	// FS describes the file system, e.g. `os.dirFS("/src/project")` or "embed.FS"
+	FS string
+	// Err is the underlying error
+	Err error
+}
+
+func (e *MissingFileError) Error() string {
+	return fmt.Sprintf("file %q not found in %s", e.Path, e.FS)
+}
+
+func (e *MissingFileError) Unwrap() error {
+	return e.Err
+}
+
+// Read returns the contents of the named file in fsys.
+// Returns a *MissingFileError naming the path and the file system if the file does not exist.
+func Read(fsys fs.FS, name string) ([]byte, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err == nil {
+		return data, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &MissingFileError{Path: name, FS: Describe(fsys), Err: err}
+	}
+
+	return nil, fmt.Errorf("could not read %q from %s: %w", name, Describe(fsys), err)
+}
+
+// Describe returns a short description of a file system for error messages.
+// File systems backed by a directory, such as os.DirFS, include the directory.
+func Describe(fsys fs.FS) string {
+	if stringer, ok := fsys.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	if value := reflect.ValueOf(fsys); value.Kind() == reflect.String {
+		return fmt.Sprintf("%T(%q)", fsys, value.String())
+	}
+
+	return fmt.Sprintf("%T", fsys)
+}
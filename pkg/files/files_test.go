@@ -0,0 +1,101 @@
+package files
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+//go:embed files.go
+var embedded embed.FS
+
+type namedFS struct {
+	fstest.MapFS
+}
+
+func (namedFS) String() string { return "named test fs" }
+
+func TestRead(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/guide.md": {Data: []byte("# Guide\n")},
+	}
+
+	data, err := Read(fsys, "docs/guide.md")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if string(data) != "# Guide\n" {
+		t.Errorf("Read() = %q, want %q", data, "# Guide\n")
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fs.FS
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "map fs",
+			fsys:    fstest.MapFS{},
+			path:    "samples/basics.go",
+			wantErr: `file "samples/basics.go" not found in fstest.MapFS`,
+		},
+		{
+			name:    "embed fs",
+			fsys:    embedded,
+			path:    "missing.go",
+			wantErr: `file "missing.go" not found in embed.FS`,
+		},
+		{
+			name:    "dir fs names the directory",
+			fsys:    os.DirFS("testdata-does-not-exist"),
+			path:    "Makefile",
+			wantErr: `file "Makefile" not found in os.dirFS("testdata-does-not-exist")`,
+		},
+		{
+			name:    "stringer fs",
+			fsys:    namedFS{fstest.MapFS{}},
+			path:    "README.md",
+			wantErr: `file "README.md" not found in named test fs`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.fsys, tt.path)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Read() error = %v, want %q", err, tt.wantErr)
+			}
+
+			var missing *MissingFileError
+			if !errors.As(err, &missing) || missing.Path != tt.path {
+				t.Errorf("Read() error = %#v, want *MissingFileError for %s", err, tt.path)
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Read() error = %v, should wrap fs.ErrNotExist", err)
+			}
+		})
+	}
+}
+
+func TestReadOtherErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs": {Mode: fs.ModeDir},
+	}
+
+	_, err := Read(fsys, "docs")
+	if err == nil || !strings.Contains(err.Error(), `could not read "docs" from fstest.MapFS`) {
+		t.Errorf("Read() error = %v, want read error", err)
+	}
+
+	var missing *MissingFileError
+	if errors.As(err, &missing) {
+		t.Errorf("Read() error = %v, should not be a MissingFileError", err)
+	}
+}
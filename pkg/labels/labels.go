@@ -0,0 +1,136 @@
+// Package labels provides a shared catalogue of issue labels.
+//
+// The same labels are used by the contributing guide to link to filtered issue
+// searches and by issue templates to label new issues, so the names only need
+// to be defined once.
+//
+// Basic usage:
+//
+//	catalogue := labels.Default()
+//	beginner, err := catalogue.Lookup(labels.GOOD_FIRST_ISSUE, labels.HELP_WANTED)
+//	if err != nil {
+//		// handle error
+//	}
+package labels
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the labels in the default catalogue, matching GitHub's default repository labels.
+const (
+	BUG              = "bug"
+	DOCUMENTATION    = "documentation"
+	ENHANCEMENT      = "enhancement"
+	GOOD_FIRST_ISSUE = "good first issue"
+	HELP_WANTED      = "help wanted"
+)
+
+// Label is an issue label.
+type Label struct {
+	// Name is the label as it appears on the forge, e.g. "good first issue"
+	Name string
+	// Description explains what the label is used for
+	Description string
+	// Color is the label's hex color without the leading "#"
+	Color string
+}
+
+// Valid returns an error if the label cannot be used.
+func (l Label) Valid() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return fmt.Errorf("label name cannot be empty")
+	}
+	if strings.Contains(l.Name, ",") {
+		return fmt.Errorf("label name %q cannot contain a comma", l.Name)
+	}
+
+	return nil
+}
+
+// Bug returns the label for reported bugs.
+func Bug() Label {
+	return Label{Name: BUG, Description: "Something isn't working", Color: "d73a4a"}
+}
+
+// Documentation returns the label for documentation improvements.
+func Documentation() Label {
+	return Label{Name: DOCUMENTATION, Description: "Improvements or additions to documentation", Color: "0075ca"}
+}
+
+// Enhancement returns the label for feature requests.
+func Enhancement() Label {
+	return Label{Name: ENHANCEMENT, Description: "New feature or request", Color: "a2eeef"}
+}
+
+// GoodFirstIssue returns the label for issues suited to new contributors.
+func GoodFirstIssue() Label {
+	return Label{Name: GOOD_FIRST_ISSUE, Description: "Good for newcomers", Color: "7057ff"}
+}
+
+// HelpWanted returns the label for issues maintainers want help with.
+func HelpWanted() Label {
+	return Label{Name: HELP_WANTED, Description: "Extra attention is needed", Color: "008672"}
+}
+
+// Catalogue is a set of labels used across a project's templates.
+type Catalogue []Label
+
+// Default returns the catalogue of labels used by the templates.
+func Default() Catalogue {
+	return Catalogue{
+		Bug(),
+		Documentation(),
+		Enhancement(),
+		GoodFirstIssue(),
+		HelpWanted(),
+	}
+}
+
+// Lookup returns the labels with the given names, in the order requested.
+// Returns an error naming the first label missing from the catalogue.
+func (c Catalogue) Lookup(names ...string) ([]Label, error) {
+	found := make([]Label, 0, len(names))
+
+	for _, name := range names {
+		label, ok := c.get(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown label %q, expected one of %s", name, strings.Join(c.Names(), ", "))
+		}
+
+		found = append(found, label)
+	}
+
+	return found, nil
+}
+
+// Names returns the names of the labels in the catalogue.
+func (c Catalogue) Names() []string {
+	names := make([]string, len(c))
+	for idx, label := range c {
+		names[idx] = label.Name
+	}
+
+	return names
+}
+
+func (c Catalogue) get(name string) (Label, bool) {
+	for _, label := range c {
+		if strings.EqualFold(label.Name, name) {
+			return label, true
+		}
+	}
+
+	return Label{}, false
+}
+
+// Join returns the label names separated by commas, as issue template frontmatter expects.
+func Join(labels []Label) string {
+	names := make([]string, len(labels))
+	for idx, label := range labels {
+		names[idx] = label.Name
+	}
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,93 @@
+package labels
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "keeps requested order",
+			names: []string{HELP_WANTED, GOOD_FIRST_ISSUE},
+			want:  []string{HELP_WANTED, GOOD_FIRST_ISSUE},
+		},
+		{
+			name:  "case insensitive",
+			names: []string{"Documentation"},
+			want:  []string{DOCUMENTATION},
+		},
+		{
+			name:  "no names",
+			names: nil,
+			want:  []string{},
+		},
+		{
+			name:    "unknown label",
+			names:   []string{BUG, "wontfix"},
+			wantErr: `unknown label "wontfix", expected one of bug, documentation`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := Default().Lookup(tt.names...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Lookup() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+
+			got := Catalogue(found).Names()
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   Label
+		wantErr string
+	}{
+		{name: "default label", label: GoodFirstIssue()},
+		{name: "empty name", label: Label{Name: "  "}, wantErr: "label name cannot be empty"},
+		{name: "comma in name", label: Label{Name: "bug,critical"}, wantErr: "cannot contain a comma"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.label.Valid()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Valid() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Valid() error = %v, should contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got := Join(nil); got != "" {
+		t.Errorf("Join() = %q, want empty", got)
+	}
+
+	if got := Join([]Label{Bug(), HelpWanted()}); got != "bug,help wanted" {
+		t.Errorf("Join() = %q, want %q", got, "bug,help wanted")
+	}
+}
@@ -0,0 +1,154 @@
+// Package makefile extracts documented targets from a Makefile.
+//
+// Descriptions come from the comment lines directly above a target (a .PHONY
+// declaration in between is allowed), or from the echo lines of a help target
+// in the common "  name - description" form.
+//
+// Basic usage:
+//
+//	targets, err := makefile.ParseFile(os.DirFS("."), "Makefile")
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	for _, target := range targets {
+//		fmt.Println(target.Name, target.Description)
+//	}
+package makefile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/files"
+)
+
+// HELP_TARGET is the name of the target whose echo lines document other targets.
+const HELP_TARGET = "help"
+
+// Target is a rule declared in a Makefile.
+type Target struct {
+	// Name is the target name, e.g. "test/unit"
+	Name string
+	// Description is the documentation found for the target, if any
+	Description string
+	// Phony reports whether the target is declared in .PHONY
+	Phony bool
+}
+
+var (
+	targetPattern   = regexp.MustCompile(`^([A-Za-z0-9_./-]+(?:[ \t]+[A-Za-z0-9_./-]+)*)[ \t]*::?(?:[^=]|$)`)
+	helpEchoPattern = regexp.MustCompile(`^@?echo[ \t]+["']?[ \t]*([A-Za-z0-9_./-]+)[ \t]+-+[ \t]+(.+?)["']?[ \t]*$`)
+)
+
+// Parse reads a Makefile and returns its targets in declaration order.
+// Special targets such as .PHONY and pattern rules are skipped.
+func Parse(r io.Reader) ([]Target, error) {
+	var (
+		targets  []Target
+		index    = map[string]int{}
+		phony    = map[string]bool{}
+		helpDocs = map[string]string{}
+		comments []string
+		current  string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Recipe lines belong to the current target
+		if strings.HasPrefix(line, "\t") {
+			if current == HELP_TARGET {
+				if match := helpEchoPattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+					helpDocs[match[1]] = strings.TrimSpace(match[2])
+				}
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			comments = nil
+			current = ""
+		case strings.HasPrefix(trimmed, "#"):
+			comments = append(comments, strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+		case strings.HasPrefix(trimmed, ".PHONY:"):
+			for _, name := range strings.Fields(strings.TrimPrefix(trimmed, ".PHONY:")) {
+				phony[name] = true
+			}
+		default:
+			match := targetPattern.FindStringSubmatch(trimmed)
+			if match == nil {
+				// Variable assignments, directives and conditionals
+				comments = nil
+				current = ""
+				continue
+			}
+
+			for _, name := range strings.Fields(match[1]) {
+				current = name
+				if strings.HasPrefix(name, ".") {
+					continue
+				}
+
+				if _, seen := index[name]; seen {
+					continue
+				}
+
+				index[name] = len(targets)
+				targets = append(targets, Target{
+					Name:        name,
+					Description: strings.Join(comments, " "),
+				})
+			}
+
+			comments = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read makefile: %w", err)
+	}
+
+	for idx := range targets {
+		targets[idx].Phony = phony[targets[idx].Name]
+
+		if targets[idx].Description == "" {
+			targets[idx].Description = helpDocs[targets[idx].Name]
+		}
+	}
+
+	return targets, nil
+}
+
+// ParseFile reads and parses the Makefile at name within fsys.
+// Returns a *files.MissingFileError if the Makefile does not exist.
+func ParseFile(fsys fs.FS, name string) ([]Target, error) {
+	data, err := files.Read(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+// Documented returns only the targets that have a description.
+func Documented(targets []Target) []Target {
+	var documented []Target
+
+	for _, target := range targets {
+		if target.Description != "" {
+			documented = append(documented, target)
+		}
+	}
+
+	return documented
+}
@@ -0,0 +1,122 @@
+package makefile
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/files"
+)
+
+const sample = `GOCMD=go
+GOTEST=$(GOCMD) test
+VERSION := $(shell git describe)
+
+.PHONY: check-tools
+check-tools:
+	@which $(GOCMD) >/dev/null 2>&1
+
+# Format all go files
+.PHONY: fmt
+fmt: check-tools
+	@$(GOCMD) fmt ./...
+
+# Run tests
+# with the race detector
+.PHONY: test/unit
+test/unit: check-tools
+	@$(GOTEST) -race ./...
+
+build: fmt
+	@$(GOCMD) build ./...
+
+%.o: %.c
+	cc -c $<
+
+.PHONY: help
+help:
+	@echo "Available targets:"
+	@echo "  build             - Builds the binary"
+	@echo "  fmt               - Formats Go source files"
+
+.DEFAULT_GOAL := help
+`
+
+func TestParse(t *testing.T) {
+	targets, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Target{
+		{Name: "check-tools", Description: "", Phony: true},
+		{Name: "fmt", Description: "Format all go files", Phony: true},
+		{Name: "test/unit", Description: "Run tests with the race detector", Phony: true},
+		{Name: "build", Description: "Builds the binary", Phony: false},
+		{Name: "help", Description: "", Phony: true},
+	}
+
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("Parse() = %+v, want %+v", targets, want)
+	}
+}
+
+func TestParseMultipleTargets(t *testing.T) {
+	targets, err := Parse(strings.NewReader("# Clean up\nclean distclean:\n\trm -rf build\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("Parse() returned %d targets, want 2", len(targets))
+	}
+
+	for _, target := range targets {
+		if target.Description != "Clean up" {
+			t.Errorf("Parse() %s description = %q, want %q", target.Name, target.Description, "Clean up")
+		}
+	}
+}
+
+func TestDocumented(t *testing.T) {
+	targets := []Target{
+		{Name: "fmt", Description: "Format"},
+		{Name: "check-tools"},
+		{Name: "test", Description: "Test"},
+	}
+
+	documented := Documented(targets)
+	if len(documented) != 2 {
+		t.Fatalf("Documented() returned %d targets, want 2", len(documented))
+	}
+	if documented[0].Name != "fmt" || documented[1].Name != "test" {
+		t.Errorf("Documented() = %+v, want fmt and test", documented)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Makefile": {Data: []byte(sample)},
+	}
+
+	targets, err := ParseFile(fsys, "Makefile")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if len(targets) != 5 {
+		t.Errorf("ParseFile() returned %d targets, want 5", len(targets))
+	}
+
+	_, err = ParseFile(fsys, "GNUmakefile")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ParseFile() error = %v, want fs.ErrNotExist", err)
+	}
+
+	var missing *files.MissingFileError
+	if !errors.As(err, &missing) || missing.Path != "GNUmakefile" {
+		t.Errorf("ParseFile() error = %v, want *files.MissingFileError for GNUmakefile", err)
+	}
+}
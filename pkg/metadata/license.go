@@ -0,0 +1,92 @@
+package metadata
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// LICENSE_FILES lists the file names checked for a license, in order.
+var LICENSE_FILES = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "COPYING"}
+
+// License describes the license a project is distributed under.
+type License struct {
+	// SPDX is the SPDX license identifier, e.g. "MIT" or "Apache-2.0"
+	SPDX string
+	// Name is the human readable license name, e.g. "MIT" or "Apache 2.0"
+	Name string
+	// Path is the relative path to the license file
+	Path string
+}
+
+// Known reports whether the license was identified.
+func (l License) Known() bool {
+	return l.SPDX != ""
+}
+
+type licenseMatcher struct {
+	spdx     string
+	name     string
+	contains []string
+}
+
+// Order matters: more specific licenses must be checked before the licenses they resemble
+var licenseMatchers = []licenseMatcher{
+	{spdx: "AGPL-3.0", name: "AGPL 3.0", contains: []string{"gnu affero general public license", "version 3"}},
+	{spdx: "LGPL-3.0", name: "LGPL 3.0", contains: []string{"gnu lesser general public license", "version 3"}},
+	{spdx: "GPL-3.0", name: "GPL 3.0", contains: []string{"gnu general public license", "version 3"}},
+	{spdx: "GPL-2.0", name: "GPL 2.0", contains: []string{"gnu general public license", "version 2"}},
+	{spdx: "Apache-2.0", name: "Apache 2.0", contains: []string{"apache license", "version 2.0"}},
+	{spdx: "MPL-2.0", name: "MPL 2.0", contains: []string{"mozilla public license", "2.0"}},
+	{spdx: "BSD-3-Clause", name: "BSD 3-Clause", contains: []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{spdx: "BSD-2-Clause", name: "BSD 2-Clause", contains: []string{"redistribution and use in source and binary forms"}},
+	{spdx: "ISC", name: "ISC", contains: []string{"permission to use, copy, modify, and/or distribute this software"}},
+	{spdx: "MIT", name: "MIT", contains: []string{"permission is hereby granted, free of charge"}},
+	{spdx: "Unlicense", name: "Unlicense", contains: []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+// IdentifyLicense returns the SPDX identifier and display name for license text.
+// Both are empty if the license is not recognised.
+func IdentifyLicense(text string) (string, string) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	for _, matcher := range licenseMatchers {
+		matched := true
+		for _, fragment := range matcher.contains {
+			if !strings.Contains(normalized, fragment) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return matcher.spdx, matcher.name
+		}
+	}
+
+	return "", ""
+}
+
+// DetectLicense finds the license file at the root of fsys and identifies it.
+// If a license file exists but is not recognised, the returned License has
+// only its Path set.
+func DetectLicense(fsys fs.FS) (License, error) {
+	for _, name := range LICENSE_FILES {
+		content, ok, err := readOptional(fsys, name)
+		if err != nil {
+			return License{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		spdx, displayName := IdentifyLicense(string(content))
+
+		return License{
+			SPDX: spdx,
+			Name: displayName,
+			Path: "./" + name,
+		}, nil
+	}
+
+	return License{}, nil
+}
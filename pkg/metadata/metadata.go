@@ -0,0 +1,257 @@
+// Package metadata detects project information from the files in a repository.
+//
+// It reads go.mod for the module path and Go version, the local git configuration
+// for the origin remote and default branch, and the LICENSE file for the license
+// in use. The result is a ProjectInfo that the templates accept in place of
+// repeated string arguments.
+//
+// Basic usage:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	doc, err := contributing.NewFromProject(info)
+//
+// Every input is optional. Missing files leave the corresponding fields empty, as do
+// worktrees and submodules, whose git directory lives elsewhere, and origin remotes
+// that are local paths; only malformed files produce an error.
+package metadata
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+// DEFAULT_BRANCH is used when the default branch cannot be detected.
+const DEFAULT_BRANCH = "main"
+
+// ProjectInfo describes a project as detected from its repository.
+type ProjectInfo struct {
+	// ModulePath is the module path declared in go.mod
+	ModulePath string
+	// GoVersion is the go directive declared in go.mod
+	GoVersion string
+	// RemoteUrl is the url of the origin remote as written in the git config
+	RemoteUrl string
+	// Repository is the parsed origin remote, or the module path when it names a known forge
+	Repository repourl.URL
+	// DefaultBranch is the branch origin/HEAD points to, falling back to the checked out branch
+	DefaultBranch string
+	// License is the license detected from the LICENSE file
+	License License
+}
+
+// HasRepository reports whether a repository location was detected.
+func (p ProjectInfo) HasRepository() bool {
+	return p.Repository.Name != ""
+}
+
+// InstallCommand returns the command used to add the module as a dependency.
+// Returns an empty string when no module path was detected.
+func (p ProjectInfo) InstallCommand() string {
+	if p.ModulePath == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("go get %s", p.ModulePath)
+}
+
+// Detect reads project information from the repository rooted at fsys.
+//
+// Example:
+//
+//	info, err := metadata.Detect(os.DirFS("/path/to/repo"))
+func Detect(fsys fs.FS) (ProjectInfo, error) {
+	var info ProjectInfo
+
+	if err := detectGoMod(fsys, &info); err != nil {
+		return ProjectInfo{}, err
+	}
+
+	if err := detectGit(fsys, &info); err != nil {
+		return ProjectInfo{}, err
+	}
+
+	license, err := DetectLicense(fsys)
+	if err != nil {
+		return ProjectInfo{}, err
+	}
+	info.License = license
+
+	// Fall back to the module path for modules hosted on a known forge
+	if !info.HasRepository() && info.ModulePath != "" {
+		repository, err := repourl.Parse("https://" + info.ModulePath)
+		if err == nil && repository.Forge != repourl.Unknown {
+			info.Repository = repository
+		}
+	}
+
+	if info.DefaultBranch == "" {
+		info.DefaultBranch = DEFAULT_BRANCH
+	}
+
+	return info, nil
+}
+
+func readOptional(fsys fs.FS, name string) ([]byte, bool, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read %s: %w", name, err)
+	}
+
+	return content, true, nil
+}
+
+func detectGoMod(fsys fs.FS, info *ProjectInfo) error {
+	content, ok, err := readOptional(fsys, "go.mod")
+	if err != nil || !ok {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "//"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "module":
+			if len(fields) != 2 {
+				return fmt.Errorf("go.mod:%d: malformed module directive", lineNumber)
+			}
+			info.ModulePath = strings.Trim(fields[1], "\"`")
+		case "go":
+			if len(fields) != 2 {
+				return fmt.Errorf("go.mod:%d: malformed go directive", lineNumber)
+			}
+			info.GoVersion = fields[1]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read go.mod: %w", err)
+	}
+
+	if info.ModulePath == "" {
+		return fmt.Errorf("go.mod does not declare a module path")
+	}
+
+	return nil
+}
+
+func detectGit(fsys fs.FS, info *ProjectInfo) error {
+	// In worktrees and submodules .git is a file pointing outside the repository,
+	// so git is treated as not detected
+	stat, err := fs.Stat(fsys, ".git")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read .git: %w", err)
+	}
+	if !stat.IsDir() {
+		return nil
+	}
+
+	config, ok, err := readOptional(fsys, ".git/config")
+	if err != nil || !ok {
+		return err
+	}
+
+	remotes, initBranch := parseGitConfig(config)
+	if remote, ok := remotes["origin"]; ok {
+		info.RemoteUrl = remote
+
+		// Remotes that are local paths name no forge, so the repository is left undetected
+		if repository, err := repourl.Parse(remote); err == nil {
+			info.Repository = repository
+		}
+	}
+
+	// origin/HEAD records the remote's default branch when the repository was cloned
+	if ref, ok, err := readOptional(fsys, ".git/refs/remotes/origin/HEAD"); err != nil {
+		return err
+	} else if ok {
+		info.DefaultBranch = branchFromRef(ref, "refs/remotes/origin/")
+	}
+
+	if info.DefaultBranch == "" && initBranch != "" {
+		info.DefaultBranch = initBranch
+	}
+
+	if info.DefaultBranch == "" {
+		if ref, ok, err := readOptional(fsys, ".git/HEAD"); err != nil {
+			return err
+		} else if ok {
+			info.DefaultBranch = branchFromRef(ref, "refs/heads/")
+		}
+	}
+
+	return nil
+}
+
+func branchFromRef(content []byte, prefix string) string {
+	ref := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(ref, "ref: ") {
+		return ""
+	}
+
+	return strings.TrimPrefix(path.Clean(strings.TrimPrefix(ref, "ref: ")), prefix)
+}
+
+// parseGitConfig extracts remote urls keyed by remote name, and init.defaultBranch.
+func parseGitConfig(content []byte) (map[string]string, string) {
+	remotes := map[string]string{}
+	var defaultBranch string
+	var section, subsection string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			header := strings.TrimSpace(line[1 : len(line)-1])
+			section, subsection, _ = strings.Cut(header, " ")
+			section = strings.ToLower(section)
+			subsection = strings.Trim(strings.TrimSpace(subsection), "\"")
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), "\"")
+
+		switch {
+		case section == "remote" && key == "url":
+			remotes[subsection] = value
+		case section == "init" && key == "defaultbranch":
+			defaultBranch = value
+		}
+	}
+
+	return remotes, defaultBranch
+}
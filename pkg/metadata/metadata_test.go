@@ -0,0 +1,236 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+const mitLicense = `MIT License
+
+Copyright (c) 2025 Someone
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction.
+`
+
+const apacheLicense = `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/
+`
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name              string
+		fsys              fstest.MapFS
+		wantModulePath    string
+		wantGoVersion     string
+		wantRepository    string
+		wantForge         repourl.Forge
+		wantDefaultBranch string
+		wantLicense       License
+	}{
+		{
+			name:              "empty repository",
+			fsys:              fstest.MapFS{},
+			wantDefaultBranch: DEFAULT_BRANCH,
+		},
+		{
+			name: "go module without git falls back to module path",
+			fsys: fstest.MapFS{
+				"go.mod":  {Data: []byte("module github.com/user/project\n\ngo 1.23.7\n\nrequire example.com/dep v1.0.0\n")},
+				"LICENSE": {Data: []byte(mitLicense)},
+			},
+			wantModulePath:    "github.com/user/project",
+			wantGoVersion:     "1.23.7",
+			wantRepository:    "https://github.com/user/project",
+			wantForge:         repourl.GitHub,
+			wantDefaultBranch: DEFAULT_BRANCH,
+			wantLicense:       License{SPDX: "MIT", Name: "MIT", Path: "./LICENSE"},
+		},
+		{
+			name: "origin remote wins over module path",
+			fsys: fstest.MapFS{
+				"go.mod": {Data: []byte("module example.com/vanity/project // vanity import\ngo 1.22\n")},
+				".git/config": {Data: []byte(`[core]
+	bare = false
+[remote "upstream"]
+	url = https://github.com/upstream/project.git
+[remote "origin"]
+	url = git@gitlab.com:group/sub/project.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+`)},
+				".git/refs/remotes/origin/HEAD": {Data: []byte("ref: refs/remotes/origin/trunk\n")},
+				".git/HEAD":                     {Data: []byte("ref: refs/heads/feature/x\n")},
+				"LICENSE.md":                    {Data: []byte(apacheLicense)},
+			},
+			wantModulePath:    "example.com/vanity/project",
+			wantGoVersion:     "1.22",
+			wantRepository:    "https://gitlab.com/group/sub/project",
+			wantForge:         repourl.GitLab,
+			wantDefaultBranch: "trunk",
+			wantLicense:       License{SPDX: "Apache-2.0", Name: "Apache 2.0", Path: "./LICENSE.md"},
+		},
+		{
+			name: "default branch from checked out branch",
+			fsys: fstest.MapFS{
+				".git/config": {Data: []byte("[remote \"origin\"]\n\turl = https://github.com/user/project\n")},
+				".git/HEAD":   {Data: []byte("ref: refs/heads/develop\n")},
+			},
+			wantRepository:    "https://github.com/user/project",
+			wantForge:         repourl.GitHub,
+			wantDefaultBranch: "develop",
+		},
+		{
+			name: "default branch from init config",
+			fsys: fstest.MapFS{
+				".git/config": {Data: []byte("[init]\n\tdefaultBranch = trunk\n")},
+				".git/HEAD":   {Data: []byte("ref: refs/heads/develop\n")},
+			},
+			wantDefaultBranch: "trunk",
+		},
+		{
+			name: "local origin remote leaves repository undetected",
+			fsys: fstest.MapFS{
+				".git/config": {Data: []byte("[remote \"origin\"]\n\turl = /srv/git/project.git\n")},
+				".git/HEAD":   {Data: []byte("ref: refs/heads/develop\n")},
+			},
+			wantDefaultBranch: "develop",
+		},
+		{
+			name: "file origin remote falls back to module path",
+			fsys: fstest.MapFS{
+				"go.mod":      {Data: []byte("module github.com/user/project\n")},
+				".git/config": {Data: []byte("[remote \"origin\"]\n\turl = file:///srv/git/project.git\n")},
+			},
+			wantModulePath:    "github.com/user/project",
+			wantRepository:    "https://github.com/user/project",
+			wantForge:         repourl.GitHub,
+			wantDefaultBranch: DEFAULT_BRANCH,
+		},
+		{
+			name: "git file of a worktree or submodule",
+			fsys: fstest.MapFS{
+				".git": {Data: []byte("gitdir: /srv/src/project/.git/worktrees/feature\n")},
+			},
+			wantDefaultBranch: DEFAULT_BRANCH,
+		},
+		{
+			name: "unrecognised license keeps path",
+			fsys: fstest.MapFS{
+				"COPYING": {Data: []byte("All rights reserved.")},
+			},
+			wantDefaultBranch: DEFAULT_BRANCH,
+			wantLicense:       License{Path: "./COPYING"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Detect(tt.fsys)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			if info.ModulePath != tt.wantModulePath {
+				t.Errorf("Detect() module path = %v, want %v", info.ModulePath, tt.wantModulePath)
+			}
+			if info.GoVersion != tt.wantGoVersion {
+				t.Errorf("Detect() go version = %v, want %v", info.GoVersion, tt.wantGoVersion)
+			}
+			if tt.wantRepository == "" && info.HasRepository() {
+				t.Errorf("Detect() repository = %v, want none", info.Repository.WebURL())
+			}
+			if tt.wantRepository != "" {
+				if got := info.Repository.WebURL(); got != tt.wantRepository {
+					t.Errorf("Detect() repository = %v, want %v", got, tt.wantRepository)
+				}
+				if info.Repository.Forge != tt.wantForge {
+					t.Errorf("Detect() forge = %v, want %v", info.Repository.Forge, tt.wantForge)
+				}
+			}
+			if info.DefaultBranch != tt.wantDefaultBranch {
+				t.Errorf("Detect() default branch = %v, want %v", info.DefaultBranch, tt.wantDefaultBranch)
+			}
+			if info.License != tt.wantLicense {
+				t.Errorf("Detect() license = %+v, want %+v", info.License, tt.wantLicense)
+			}
+		})
+	}
+}
+
+func TestDetectErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		errMsg string
+	}{
+		{
+			name: "go.mod without module",
+			fsys: fstest.MapFS{
+				"go.mod": {Data: []byte("go 1.23\n")},
+			},
+			errMsg: "does not declare a module path",
+		},
+		{
+			name: "malformed module directive",
+			fsys: fstest.MapFS{
+				"go.mod": {Data: []byte("module\n")},
+			},
+			errMsg: "go.mod:1: malformed module directive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Detect(tt.fsys)
+			if err == nil {
+				t.Fatalf("Detect() expected error containing %q", tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Detect() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestIdentifyLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantSPDX string
+	}{
+		{name: "mit", text: mitLicense, wantSPDX: "MIT"},
+		{name: "apache", text: apacheLicense, wantSPDX: "Apache-2.0"},
+		{name: "gpl3", text: "GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", wantSPDX: "GPL-3.0"},
+		{name: "lgpl3", text: "GNU LESSER GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007", wantSPDX: "LGPL-3.0"},
+		{name: "bsd3", text: "Redistribution and use in source and binary forms, with or without\nmodification... Neither the name of the copyright holder", wantSPDX: "BSD-3-Clause"},
+		{name: "bsd2", text: "Redistribution and use in source and binary forms, with or without modification", wantSPDX: "BSD-2-Clause"},
+		{name: "mpl", text: "Mozilla Public License Version 2.0", wantSPDX: "MPL-2.0"},
+		{name: "unknown", text: "Proprietary", wantSPDX: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spdx, _ := IdentifyLicense(tt.text)
+			if spdx != tt.wantSPDX {
+				t.Errorf("IdentifyLicense() = %v, want %v", spdx, tt.wantSPDX)
+			}
+		})
+	}
+}
+
+func TestInstallCommand(t *testing.T) {
+	if got := (ProjectInfo{}).InstallCommand(); got != "" {
+		t.Errorf("InstallCommand() = %v, want empty", got)
+	}
+
+	info := ProjectInfo{ModulePath: "github.com/user/project"}
+	if got := info.InstallCommand(); got != "go get github.com/user/project" {
+		t.Errorf("InstallCommand() = %v, want go get github.com/user/project", got)
+	}
+}
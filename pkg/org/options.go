@@ -0,0 +1,189 @@
+package org
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+)
+
+// ReadmeOptions returns the profile's README options followed by opts, the repository's
+// own, so options in opts win. The profile's values still in place once the options
+// have run are added to report, which may be nil.
+//
+// Example:
+//
+//	var report org.Report
+//	doc, err := readme.New(props, extraSections, profile.ReadmeOptions(&report, readme.WithTableOfContents(2))...)
+func (p Profile) ReadmeOptions(report *Report, opts ...readme.Option) []readme.Option {
+	var license doyoucompute.Section
+
+	profileOpts := []readme.Option{valid[readme.ReadmeProps](p)}
+
+	if p.License.Name != "" {
+		license = readme.NamedLicense(p.License.Name, p.License.path())
+		profileOpts = append(profileOpts, readme.WithLicense(p.License.Name, p.License.path()))
+	}
+
+	return withReport(profileOpts, opts, func(props *readme.ReadmeProps) error {
+		report = p.reportTo(report)
+
+		if p.License.Name != "" && reflect.DeepEqual(props.License, license) {
+			report.add("readme.license", p.License.Name)
+		}
+
+		return nil
+	})
+}
+
+// ContributingOptions returns the profile's contributing guide options followed by opts,
+// the repository's own, so options in opts win. The security section is added once the
+// guide's sections are in place, unless the repository adds its own. The profile's values
+// still in place once the options have run are added to report, which may be nil.
+//
+// Example:
+//
+//	var report org.Report
+//	doc, err := contributing.New(projectUrl, "", profile.ContributingOptions(&report, contributing.WithDCO())...)
+func (p Profile) ContributingOptions(report *Report, opts ...contributing.Option) []contributing.Option {
+	var issues string
+
+	profileOpts := []contributing.Option{valid[contributing.ContributingProps](p)}
+
+	if p.License.SPDX != "" {
+		profileOpts = append(profileOpts, contributing.WithLicenseID(p.License.SPDX, p.License.path()))
+	}
+
+	if p.IssueTrackerHost != "" {
+		profileOpts = append(profileOpts, func(props *contributing.ContributingProps) (doyoucompute.Finalizer[contributing.ContributingProps], error) {
+			var err error
+
+			issues, err = p.issueTracker(props.Repository.WebURL())
+			if err != nil {
+				return nil, err
+			}
+
+			return contributing.WithIssueTrackerUrl(issues)(props)
+		})
+	}
+
+	return withReport(profileOpts, opts, func(props *contributing.ContributingProps) error {
+		report = p.reportTo(report)
+
+		if p.License.SPDX != "" && reflect.DeepEqual(props.License, contributing.DefaultLicenseFor(p.License.SPDX, p.License.path())) {
+			report.add("contributing.license_id", p.License.SPDX)
+		}
+
+		if issues != "" && props.IssueTrackerUrl == issues {
+			report.add("contributing.issue_tracker_url", issues)
+		}
+
+		props.Edits = append(props.Edits, p.securityEdit("contributing.sections", report))
+
+		return nil
+	})
+}
+
+// BugReportOptions returns the profile's bug report options followed by opts, the
+// repository's own, so options in opts win. The assignees are only set when the
+// frontmatter has none once the options have run, and the security section is added
+// unless the repository adds its own. The profile's values still in place once the
+// options have run are added to report, which may be nil.
+//
+// Example:
+//
+//	var report org.Report
+//	doc, err := bugreport.New(profile.BugReportOptions(&report, bugreport.WithName("Widget bug"))...)
+func (p Profile) BugReportOptions(report *Report, opts ...bugreport.Option) []bugreport.Option {
+	profileOpts := []bugreport.Option{valid[bugreport.BugReportProps](p)}
+
+	if len(p.Labels) > 0 {
+		profileOpts = append(profileOpts, bugreport.WithLabels(slices.Clone(p.Labels)...))
+	}
+
+	return withReport(profileOpts, opts, func(props *bugreport.BugReportProps) error {
+		report = p.reportTo(report)
+
+		if len(p.Labels) > 0 && reflect.DeepEqual(props.Labels, p.Labels) {
+			report.add("bugreport.labels", joinLabels(p.Labels))
+		}
+
+		if assigned, _ := props.Frontmatter.Data["assignees"].(string); assigned == "" && len(p.Assignees) > 0 {
+			data := make(map[string]interface{}, len(props.Frontmatter.Data)+1)
+			for key, value := range props.Frontmatter.Data {
+				data[key] = value
+			}
+			data["assignees"] = strings.Join(p.Assignees, ",")
+
+			props.Frontmatter = doyoucompute.Frontmatter{Data: data}
+			report.add("bugreport.assignees", strings.Join(p.Assignees, ", "))
+		}
+
+		props.Edits = append(props.Edits, p.securityEdit("bugreport.sections", report))
+
+		return nil
+	})
+}
+
+// path returns the link to the license file, falling back to the conventional location.
+func (l License) path() string {
+	if l.Path == "" {
+		return contributing.DEFAULT_LICENSE_PATH
+	}
+
+	return l.Path
+}
+
+// reportTo returns report named after the profile, or a discarded report when it is nil.
+func (p Profile) reportTo(report *Report) *Report {
+	if report == nil {
+		report = &Report{}
+	}
+	if report.Profile == "" {
+		report.Profile = p.Name
+	}
+
+	return report
+}
+
+// securityEdit appends the security section, unless the document already has one,
+// and adds it to report at path.
+func (p Profile) securityEdit(path string, report *Report) sections.Edit {
+	return func(d *doyoucompute.Document) error {
+		if p.SecurityContact == "" || slices.Contains(sections.Names(*d), SECURITY_SECTION) {
+			return nil
+		}
+
+		if err := sections.Append(p.securitySection().Build())(d); err != nil {
+			return err
+		}
+
+		report.add(path, p.SecurityContact)
+
+		return nil
+	}
+}
+
+// valid returns an option that fails when the profile cannot be applied.
+func valid[T any](p Profile) doyoucompute.OptionBuilder[T] {
+	return func(*T) (doyoucompute.Finalizer[T], error) {
+		return nil, p.Valid()
+	}
+}
+
+// withReport returns the profile's options, then the repository's, then an option that
+// runs record once every other option and its finalizer has run.
+func withReport[T any](profileOpts, opts []doyoucompute.OptionBuilder[T], record func(props *T) error) []doyoucompute.OptionBuilder[T] {
+	all := make([]doyoucompute.OptionBuilder[T], 0, len(profileOpts)+len(opts)+1)
+	all = append(all, profileOpts...)
+	all = append(all, opts...)
+
+	return append(all, func(props *T) (doyoucompute.Finalizer[T], error) {
+		return nil, record(props)
+	})
+}
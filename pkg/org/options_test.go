@@ -0,0 +1,275 @@
+package org
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+)
+
+func render(t *testing.T, doc doyoucompute.Document) string {
+	t.Helper()
+
+	content, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	return content
+}
+
+func repoSecuritySection() doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory(SECURITY_SECTION, func(s *doyoucompute.Section) error {
+		s.WriteIntro().Text("Email widget-security@acme.example.")
+
+		return nil
+	})
+
+	return section
+}
+
+func TestReadmeOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     Profile
+		opts        []readme.Option
+		wantApplied []Value
+		wantContent string
+		wantErr     string
+	}{
+		{
+			name:        "fills the license",
+			profile:     acme(),
+			wantApplied: []Value{{Path: "readme.license", Value: "Apache 2.0"}},
+			wantContent: "Apache 2.0 License",
+		},
+		{
+			name:        "repository license wins",
+			profile:     acme(),
+			opts:        []readme.Option{readme.WithLicense("MIT", "./LICENSE")},
+			wantContent: "MIT License",
+		},
+		{
+			name:    "invalid profile",
+			profile: Profile{License: License{SPDX: "MIT"}},
+			wantErr: "license name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props := readme.ReadmeProps{
+				Name:       "Widget",
+				Intro:      *doyoucompute.NewParagraph().Text("Widgets."),
+				Features:   doyoucompute.Section{Name: "Features"},
+				QuickStart: doyoucompute.Section{Name: "Quick start"},
+			}
+
+			var report Report
+			doc, err := readme.New(props, nil, tt.profile.ReadmeOptions(&report, tt.opts...)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readme.New() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readme.New() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(report.Applied, tt.wantApplied) {
+				t.Errorf("applied = %v, want %v", report.Applied, tt.wantApplied)
+			}
+			if content := render(t, doc); !strings.Contains(content, tt.wantContent) {
+				t.Errorf("content does not contain %q:\n%s", tt.wantContent, content)
+			}
+		})
+	}
+}
+
+func TestContributingOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		licensePath string
+		opts        []contributing.Option
+		wantApplied []Value
+		wantContent []string
+	}{
+		{
+			name: "fills the license, issue tracker and security section",
+			wantApplied: []Value{
+				{Path: "contributing.license_id", Value: "Apache-2.0"},
+				{Path: "contributing.issue_tracker_url", Value: "https://gitlab.acme.example/acme/widget/-/issues"},
+				{Path: "contributing.sections", Value: "security@acme.example"},
+			},
+			wantContent: []string{
+				"Apache-2.0",
+				"https://gitlab.acme.example/acme/widget/-/issues",
+				"mailto:security@acme.example",
+			},
+		},
+		{
+			name:        "links the profile's license path",
+			licensePath: "./LICENSE.md",
+			wantApplied: []Value{
+				{Path: "contributing.license_id", Value: "Apache-2.0"},
+				{Path: "contributing.issue_tracker_url", Value: "https://gitlab.acme.example/acme/widget/-/issues"},
+				{Path: "contributing.sections", Value: "security@acme.example"},
+			},
+			wantContent: []string{
+				"[Apache-2.0 License.](./LICENSE.md)",
+			},
+		},
+		{
+			name: "repository values win",
+			opts: []contributing.Option{
+				contributing.WithLicenseID("MIT", ""),
+				contributing.WithIssueTrackerUrl("https://github.com/acme/widget/issues"),
+				contributing.WithSections(sections.Append(repoSecuritySection())),
+			},
+			wantContent: []string{
+				"MIT",
+				"https://github.com/acme/widget/issues",
+				"widget-security@acme.example",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := acme()
+			profile.License.Path = tt.licensePath
+
+			var report Report
+			doc, err := contributing.New("https://github.com/acme/widget", "", profile.ContributingOptions(&report, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("contributing.New() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(report.Applied, tt.wantApplied) {
+				t.Errorf("applied = %v, want %v", report.Applied, tt.wantApplied)
+			}
+
+			content := render(t, doc)
+			for _, want := range tt.wantContent {
+				if !strings.Contains(content, want) {
+					t.Errorf("content does not contain %q:\n%s", want, content)
+				}
+			}
+			if count := strings.Count(content, SECURITY_SECTION); count != 1 {
+				t.Errorf("content has %d security sections, want 1", count)
+			}
+		})
+	}
+}
+
+func TestBugReportOptions(t *testing.T) {
+	triage := Profile{
+		Name:      "Acme",
+		Labels:    []labels.Label{labels.Bug(), {Name: "needs triage"}},
+		Assignees: []string{"platform-oncall", "triage-bot"},
+	}
+
+	tests := []struct {
+		name          string
+		profile       Profile
+		opts          []bugreport.Option
+		wantApplied   []Value
+		wantLabels    string
+		wantAssignees string
+		wantErr       string
+		wantSecurity  bool
+	}{
+		{
+			name:    "custom labels and assignees",
+			profile: triage,
+			wantApplied: []Value{
+				{Path: "bugreport.labels", Value: "bug, needs triage"},
+				{Path: "bugreport.assignees", Value: "platform-oncall, triage-bot"},
+			},
+			wantLabels:    "bug,needs triage",
+			wantAssignees: "platform-oncall,triage-bot",
+		},
+		{
+			name:    "assignees survive a repository name",
+			profile: triage,
+			opts:    []bugreport.Option{bugreport.WithName("Widget bug")},
+			wantApplied: []Value{
+				{Path: "bugreport.labels", Value: "bug, needs triage"},
+				{Path: "bugreport.assignees", Value: "platform-oncall, triage-bot"},
+			},
+			wantLabels:    "bug,needs triage",
+			wantAssignees: "platform-oncall,triage-bot",
+		},
+		{
+			name:    "repository values win",
+			profile: acme(),
+			opts: []bugreport.Option{
+				bugreport.WithFrontMatter(*doyoucompute.NewFrontmatter(map[string]interface{}{"name": "Bug", "assignees": "widget-team"})),
+				bugreport.WithLabels(labels.Enhancement()),
+				bugreport.WithSections(sections.Append(repoSecuritySection())),
+			},
+			wantLabels:    "enhancement",
+			wantAssignees: "widget-team",
+		},
+		{
+			name:          "adds the security section",
+			profile:       acme(),
+			wantApplied:   []Value{{Path: "bugreport.labels", Value: "bug"}, {Path: "bugreport.assignees", Value: "platform-oncall"}, {Path: "bugreport.sections", Value: "security@acme.example"}},
+			wantLabels:    "bug",
+			wantAssignees: "platform-oncall",
+			wantSecurity:  true,
+		},
+		{
+			name:    "invalid label",
+			profile: Profile{Labels: []labels.Label{{Name: "bug,urgent"}}},
+			wantErr: "cannot contain a comma",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var report Report
+			doc, err := bugreport.New(tt.profile.BugReportOptions(&report, tt.opts...)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("bugreport.New() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bugreport.New() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(report.Applied, tt.wantApplied) {
+				t.Errorf("applied = %v, want %v", report.Applied, tt.wantApplied)
+			}
+			if report.Profile != tt.profile.Name {
+				t.Errorf("report profile = %q, want %q", report.Profile, tt.profile.Name)
+			}
+			if got := doc.Frontmatter.Data["labels"]; got != tt.wantLabels {
+				t.Errorf("frontmatter labels = %v, want %v", got, tt.wantLabels)
+			}
+			if got := doc.Frontmatter.Data["assignees"]; got != tt.wantAssignees {
+				t.Errorf("frontmatter assignees = %v, want %v", got, tt.wantAssignees)
+			}
+
+			content := render(t, doc)
+			if got := strings.Contains(content, "mailto:security@acme.example"); got != tt.wantSecurity {
+				t.Errorf("profile security section present = %v, want %v", got, tt.wantSecurity)
+			}
+		})
+	}
+}
+
+func TestOptionsWithoutReport(t *testing.T) {
+	if _, err := bugreport.New(acme().BugReportOptions(nil)...); err != nil {
+		t.Fatalf("bugreport.New() error = %v", err)
+	}
+}
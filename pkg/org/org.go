@@ -0,0 +1,296 @@
+// Package org applies organisation-wide defaults to every template in a config.
+//
+// Organisations with many repositories tend to pass the same security contact,
+// license, issue tracker, labels and assignees to every template. A Profile holds
+// those values once, so it can be published in a shared Go module and applied to
+// each repository's config in one call, or passed as options to repositories that
+// build their documents in Go. Values the repository sets itself always win, and
+// the Report lists the values that came from the profile.
+//
+// Basic usage:
+//
+//	var Acme = org.Profile{
+//		Name:             "Acme",
+//		SecurityContact:  "security@acme.example",
+//		License:          org.License{Name: "Apache 2.0", SPDX: "Apache-2.0"},
+//		IssueTrackerHost: "gitlab.acme.example",
+//		Labels:           []labels.Label{labels.Bug()},
+//		Assignees:        []string{"platform-oncall"},
+//	}
+//
+//	cfg, err := config.Load(os.DirFS("."), config.DEFAULT_PATH)
+//	if err != nil {
+//		// handle error
+//	}
+//	report, err := Acme.Apply(cfg)
+//	if err != nil {
+//		// handle error
+//	}
+//	fmt.Print(report)
+//	docs, err := templates.Documents(cfg, templates.Builtin()...)
+//
+// In Go, the profile's options come first and the repository's own options follow:
+//
+//	var report org.Report
+//	doc, err := bugreport.New(Acme.BugReportOptions(&report,
+//		bugreport.WithName("Widget bug"),
+//	)...)
+package org
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+// SECURITY_SECTION is the heading of the section the security contact is added in.
+const SECURITY_SECTION = "Reporting security issues"
+
+// License names the license the organisation's projects are published under.
+type License struct {
+	// Name is the license name shown in the README, e.g. "MIT"
+	Name string
+	// Path is the link to the license file in the README and contributing guide; defaults to ./LICENSE
+	Path string
+	// SPDX is the license identifier named in the contributing guide, e.g. "MIT"
+	SPDX string
+}
+
+// Profile holds the values an organisation uses across its repositories.
+// Every field is optional; empty fields leave the templates as they are.
+type Profile struct {
+	// Name identifies the organisation in the report
+	Name string
+	// SecurityContact is where vulnerabilities are reported, an email address or url.
+	// It is added in a section of the contributing guide and the bug report.
+	SecurityContact string
+	// License is named in the README and the contributing guide
+	License License
+	// IssueTrackerHost is the forge host issues are tracked on, when it differs from
+	// where the repository is hosted, e.g. "gitlab.acme.example" for repositories
+	// mirrored from an internal git server
+	IssueTrackerHost string
+	// Labels are applied to new bug reports
+	Labels []labels.Label
+	// Assignees are the users new bug reports are assigned to
+	Assignees []string
+}
+
+// Valid returns an error if the profile cannot be applied.
+func (p Profile) Valid() error {
+	if p.License.Name == "" && (p.License.Path != "" || p.License.SPDX != "") {
+		return errors.New("license name is required when a license path or SPDX identifier is set")
+	}
+
+	if p.IssueTrackerHost != "" {
+		tracker, err := repourl.Parse("https://" + p.IssueTrackerHost + "/owner/name")
+		if err == nil {
+			_, err = tracker.IssuesURL()
+		}
+		if err != nil {
+			return fmt.Errorf("invalid issue tracker host %q: %w", p.IssueTrackerHost, err)
+		}
+	}
+
+	for _, label := range p.Labels {
+		if err := label.Valid(); err != nil {
+			return fmt.Errorf("invalid label: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Value is a template setting that was taken from the profile.
+type Value struct {
+	// Path locates the setting in the config, e.g. "templates.readme.license",
+	// or in the document when applied as options, e.g. "readme.license"
+	Path string
+	// Value is the setting as applied
+	Value string
+}
+
+// Report lists the settings a profile filled in, in the order they were applied.
+type Report struct {
+	// Profile is the name of the profile that was applied
+	Profile string
+	// Applied holds the settings taken from the profile
+	Applied []Value
+}
+
+// FromProfile reports whether the setting at path was taken from the profile.
+func (r Report) FromProfile(path string) bool {
+	for _, value := range r.Applied {
+		if value.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String lists the applied settings one per line.
+func (r Report) String() string {
+	name := r.Profile
+	if name == "" {
+		name = "profile"
+	}
+
+	var b strings.Builder
+	for _, value := range r.Applied {
+		fmt.Fprintf(&b, "%s: %s (from %s)\n", value.Path, value.Value, name)
+	}
+
+	return b.String()
+}
+
+func (r *Report) add(path, value string) {
+	r.Applied = append(r.Applied, Value{Path: path, Value: value})
+}
+
+// Apply fills in every setting of the templates in cfg that the repository leaves
+// empty with the profile's value, and reports the settings it filled in.
+// Templates the config leaves out are not added, and settings the repository sets,
+// including a security section it writes or disables itself, are kept as they are.
+// Config files only name labels from labels.Default, so a profile with other labels
+// fails here; pass those to bug reports built in Go with BugReportOptions.
+//
+// Example:
+//
+//	report, err := profile.Apply(cfg)
+func (p Profile) Apply(cfg *config.Config) (Report, error) {
+	report := Report{Profile: p.Name}
+
+	if err := p.Valid(); err != nil {
+		return report, err
+	}
+
+	if readme := cfg.Templates.Readme; readme != nil {
+		if readme.License == nil && p.License.Name != "" {
+			readme.License = &config.License{Name: p.License.Name, Path: p.License.Path}
+			report.add("templates.readme.license", p.License.Name)
+		}
+	}
+
+	if contributing := cfg.Templates.Contributing; contributing != nil {
+		if contributing.LicenseID == "" && p.License.SPDX != "" {
+			contributing.LicenseID = p.License.SPDX
+			if contributing.LicensePath == "" {
+				contributing.LicensePath = p.License.Path
+			}
+			report.add("templates.contributing.license_id", p.License.SPDX)
+		}
+
+		if contributing.IssueTrackerUrl == "" && p.IssueTrackerHost != "" {
+			issues, err := p.issueTracker(contributing.ProjectUrl)
+			if err != nil {
+				return report, err
+			}
+
+			contributing.IssueTrackerUrl = issues
+			report.add("templates.contributing.issue_tracker_url", issues)
+		}
+
+		p.addSecurity(&contributing.Output, "templates.contributing", &report)
+	}
+
+	if bugReport := cfg.Templates.BugReport; bugReport != nil {
+		if len(bugReport.Labels) == 0 && len(p.Labels) > 0 {
+			names := make([]config.Label, len(p.Labels))
+			for idx, label := range p.Labels {
+				if _, err := labels.Default().Lookup(label.Name); err != nil {
+					return report, fmt.Errorf("could not apply labels to the config: %w", err)
+				}
+
+				names[idx] = config.Label(label.Name)
+			}
+
+			bugReport.Labels = names
+			report.add("templates.bugreport.labels", joinLabels(p.Labels))
+		}
+
+		if len(bugReport.Assignees) == 0 && len(p.Assignees) > 0 {
+			bugReport.Assignees = slices.Clone(p.Assignees)
+			report.add("templates.bugreport.assignees", strings.Join(p.Assignees, ", "))
+		}
+
+		p.addSecurity(&bugReport.Output, "templates.bugreport", &report)
+	}
+
+	return report, nil
+}
+
+// issueTracker returns the issue tracker of the repository at projectUrl on the profile's issue tracker host.
+func (p Profile) issueTracker(projectUrl string) (string, error) {
+	repository, err := repourl.Parse(projectUrl)
+	if err != nil {
+		return "", fmt.Errorf("could not apply issue tracker host %s: %w", p.IssueTrackerHost, err)
+	}
+
+	tracked, err := repourl.Parse("https://" + p.IssueTrackerHost + "/" + repository.FullPath())
+	if err != nil {
+		return "", fmt.Errorf("could not apply issue tracker host %s: %w", p.IssueTrackerHost, err)
+	}
+
+	issues, err := tracked.IssuesURL()
+	if err != nil {
+		return "", fmt.Errorf("could not apply issue tracker host %s: %w", p.IssueTrackerHost, err)
+	}
+
+	return issues, nil
+}
+
+// addSecurity appends the security section to output, unless the repository writes its own.
+// A repository that disables the section still gets it, so its disable entry names a section
+// that exists, but the section is not reported as taken from the profile.
+func (p Profile) addSecurity(output *config.Output, path string, report *Report) {
+	if p.SecurityContact == "" {
+		return
+	}
+
+	for _, section := range output.Sections {
+		if section.Name == SECURITY_SECTION {
+			return
+		}
+	}
+
+	output.Sections = append(output.Sections, p.securitySection())
+
+	if !slices.Contains(output.Disable, SECURITY_SECTION) {
+		report.add(fmt.Sprintf("%s.sections[%d]", path, len(output.Sections)-1), p.SecurityContact)
+	}
+}
+
+// securitySection returns the section naming the security contact.
+func (p Profile) securitySection() config.Section {
+	return config.Section{
+		Name:     SECURITY_SECTION,
+		Markdown: fmt.Sprintf("Please do not report security vulnerabilities in public issues. Report them privately to %s instead.", contact(p.SecurityContact)),
+	}
+}
+
+// contact links the security contact, as a mailto link when it is an email address.
+func contact(value string) string {
+	if strings.Contains(value, "://") {
+		return fmt.Sprintf("[%s](%s)", value, value)
+	}
+	if strings.Contains(value, "@") {
+		return fmt.Sprintf("[%s](mailto:%s)", value, value)
+	}
+
+	return value
+}
+
+func joinLabels(values []labels.Label) string {
+	names := make([]string, len(values))
+	for idx, label := range values {
+		names[idx] = label.Name
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,215 @@
+package org
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/templates"
+)
+
+func acme() Profile {
+	return Profile{
+		Name:             "Acme",
+		SecurityContact:  "security@acme.example",
+		License:          License{Name: "Apache 2.0", SPDX: "Apache-2.0"},
+		IssueTrackerHost: "gitlab.acme.example",
+		Labels:           []labels.Label{labels.Bug()},
+		Assignees:        []string{"platform-oncall"},
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr string
+	}{
+		{
+			name:    "full profile",
+			profile: acme(),
+		},
+		{
+			name:    "empty profile",
+			profile: Profile{},
+		},
+		{
+			name:    "license without a name",
+			profile: Profile{License: License{SPDX: "MIT"}},
+			wantErr: "license name is required when a license path or SPDX identifier is set",
+		},
+		{
+			name:    "issue tracker host of an unknown forge",
+			profile: Profile{IssueTrackerHost: "git.acme.example"},
+			wantErr: `invalid issue tracker host "git.acme.example"`,
+		},
+		{
+			name:    "custom label",
+			profile: Profile{Labels: []labels.Label{{Name: "needs triage"}}},
+		},
+		{
+			name:    "label with a comma",
+			profile: Profile{Labels: []labels.Label{{Name: "bug,urgent"}}},
+			wantErr: `invalid label: label name "bug,urgent" cannot contain a comma`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Valid()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Valid() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Valid() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		profile     Profile
+		wantApplied []Value
+		wantErr     string
+	}{
+		{
+			name:    "fills every template left empty",
+			config:  "templates:\n  readme:\n    name: Widget\n    features: Fast\n    quickstart: go get widget\n  contributing:\n    project_url: https://github.com/acme/widget\n  bugreport: {}\n",
+			profile: acme(),
+			wantApplied: []Value{
+				{Path: "templates.readme.license", Value: "Apache 2.0"},
+				{Path: "templates.contributing.license_id", Value: "Apache-2.0"},
+				{Path: "templates.contributing.issue_tracker_url", Value: "https://gitlab.acme.example/acme/widget/-/issues"},
+				{Path: "templates.contributing.sections[0]", Value: "security@acme.example"},
+				{Path: "templates.bugreport.labels", Value: "bug"},
+				{Path: "templates.bugreport.assignees", Value: "platform-oncall"},
+				{Path: "templates.bugreport.sections[0]", Value: "security@acme.example"},
+			},
+		},
+		{
+			name:    "repository values win",
+			config:  "templates:\n  readme:\n    name: Widget\n    features: Fast\n    quickstart: go get widget\n    license:\n      name: MIT\n  contributing:\n    project_url: https://github.com/acme/widget\n    issue_tracker_url: https://github.com/acme/widget/issues\n    license_id: MIT\n    disable: [Reporting security issues]\n  bugreport:\n    labels: [enhancement]\n    assignees: [widget-team]\n    sections:\n      - name: Reporting security issues\n        markdown: Email widget-security@acme.example.\n",
+			profile: acme(),
+		},
+		{
+			name:    "templates left out of the config are not added",
+			config:  "templates:\n  pullrequest: {}\n",
+			profile: acme(),
+		},
+		{
+			name:    "invalid profile",
+			config:  "templates:\n  bugreport: {}\n",
+			profile: Profile{Labels: []labels.Label{{Name: ""}}},
+			wantErr: "invalid label: label name cannot be empty",
+		},
+		{
+			name:    "label outside the default catalogue",
+			config:  "templates:\n  bugreport: {}\n",
+			profile: Profile{Labels: []labels.Label{{Name: "needs triage"}}},
+			wantErr: `could not apply labels to the config: unknown label "needs triage"`,
+		},
+		{
+			name:    "project url without an owner",
+			config:  "templates:\n  contributing:\n    project_url: https://example.com/widget\n",
+			profile: Profile{IssueTrackerHost: "gitlab.acme.example"},
+			wantErr: "could not apply issue tracker host gitlab.acme.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config.Parse() error = %v", err)
+			}
+
+			report, err := tt.profile.Apply(cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Apply() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(report.Applied, tt.wantApplied) {
+				t.Errorf("Apply() applied = %v, want %v", report.Applied, tt.wantApplied)
+			}
+
+			if _, err := templates.Documents(cfg, templates.Builtin()...); err != nil {
+				t.Errorf("templates.Documents() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyLicensePath(t *testing.T) {
+	cfg, err := config.Parse([]byte("templates:\n  readme:\n    name: Widget\n    features: Fast\n    quickstart: go get widget\n  contributing:\n    project_url: https://github.com/acme/widget\n"))
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+
+	profile := acme()
+	profile.License.Path = "./LICENSE.md"
+
+	if _, err := profile.Apply(cfg); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, template := range []templates.Template{templates.Readme(), templates.Contributing()} {
+		doc, err := template.Build(cfg)
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		if content := render(t, doc); !strings.Contains(content, "(./LICENSE.md)") {
+			t.Errorf("%s does not link the profile's license path:\n%s", doc.Name, content)
+		}
+	}
+}
+
+func TestApplyDocuments(t *testing.T) {
+	cfg, err := config.Parse([]byte("templates:\n  bugreport:\n    assignees: [widget-team]\n"))
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+
+	report, err := acme().Apply(cfg)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if !report.FromProfile("templates.bugreport.labels") {
+		t.Errorf("FromProfile(labels) = false, want true")
+	}
+	if report.FromProfile("templates.bugreport.assignees") {
+		t.Errorf("FromProfile(assignees) = true, want false")
+	}
+
+	doc, err := templates.BugReport().Build(cfg)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if got := doc.Frontmatter.Data["labels"]; got != "bug" {
+		t.Errorf("frontmatter labels = %v, want bug", got)
+	}
+	if got := doc.Frontmatter.Data["assignees"]; got != "widget-team" {
+		t.Errorf("frontmatter assignees = %v, want widget-team", got)
+	}
+
+	want := "templates.bugreport.labels: bug (from Acme)\ntemplates.bugreport.sections[0]: security@acme.example (from Acme)\n"
+	if report.String() != want {
+		t.Errorf("String() = %q, want %q", report.String(), want)
+	}
+}
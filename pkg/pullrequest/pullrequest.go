@@ -16,19 +16,58 @@
 //		pullrequest.WithName("Feature PR"),
 //		pullrequest.WithDescription(customSection),
 //	)
+//
+// Writing your own option, which runs alongside the built-in ones in the order given:
+//
+//	func WithChecklist(items ...string) pullrequest.Option {
+//		return func(p *pullrequest.PullRequestProps) (doyoucompute.Finalizer[pullrequest.PullRequestProps], error) {
+//			list := p.Testing.CreateList(doyoucompute.BULLET)
+//			for _, item := range items {
+//				list.Append("[ ] " + item)
+//			}
+//			return nil, nil
+//		}
+//	}
 package pullrequest
 
 import (
 	"fmt"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
-type pullRequestProps struct {
-	name         string
-	description  doyoucompute.Section
-	relatedIssue doyoucompute.Section
-	testing      doyoucompute.Section
+// DEFAULT_PATH is where GitHub looks for the pull request template.
+const DEFAULT_PATH = ".github/PULL_REQUEST_TEMPLATE.md"
+
+// PullRequestProps holds everything New renders. Defaults returns the values New starts
+// from, and each option then changes them in the order given, so options outside this
+// package can be written as any doyoucompute.OptionBuilder[PullRequestProps].
+type PullRequestProps struct {
+	// Document name
+	Name string
+	// Description section
+	Description doyoucompute.Section
+	// Related issue section
+	RelatedIssue doyoucompute.Section
+	// Testing section
+	Testing doyoucompute.Section
+	// Section edits applied once the sections above are in place
+	Edits []sections.Edit
+}
+
+// Option changes the props of a pull request template before it is rendered.
+type Option = doyoucompute.OptionBuilder[PullRequestProps]
+
+// Defaults returns the props New starts from before applying options.
+func Defaults() PullRequestProps {
+	return PullRequestProps{
+		Name:         DefaultName(),
+		Description:  DefaultDescription(),
+		RelatedIssue: DefaultRelatedIssue(),
+		Testing:      DefaultTesting(),
+	}
 }
 
 // WithName overrides the document name.
@@ -36,9 +75,9 @@ type pullRequestProps struct {
 // Example:
 //
 //	pullrequest.WithName("Feature: Add authentication")
-func WithName(name string) doyoucompute.OptionBuilder[pullRequestProps] {
-	return func(p *pullRequestProps) (doyoucompute.Finalizer[pullRequestProps], error) {
-		p.name = name
+func WithName(name string) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		p.Name = name
 
 		return nil, nil
 	}
@@ -52,9 +91,9 @@ func WithName(name string) doyoucompute.OptionBuilder[pullRequestProps] {
 //	section := doyoucompute.NewSection("Description")
 //	section.WriteParagraph().Text("Added OAuth2 authentication")
 //	pullrequest.WithDescription(section)
-func WithDescription(description doyoucompute.Section) doyoucompute.OptionBuilder[pullRequestProps] {
-	return func(p *pullRequestProps) (doyoucompute.Finalizer[pullRequestProps], error) {
-		p.description = description
+func WithDescription(description doyoucompute.Section) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		p.Description = description
 
 		return nil, nil
 	}
@@ -68,9 +107,9 @@ func WithDescription(description doyoucompute.Section) doyoucompute.OptionBuilde
 //	section := doyoucompute.NewSection("Related issue")
 //	section.WriteParagraph().Text("Fixes #123")
 //	pullrequest.WithRelatedIssue(section)
-func WithRelatedIssue(relatedIssue doyoucompute.Section) doyoucompute.OptionBuilder[pullRequestProps] {
-	return func(p *pullRequestProps) (doyoucompute.Finalizer[pullRequestProps], error) {
-		p.relatedIssue = relatedIssue
+func WithRelatedIssue(relatedIssue doyoucompute.Section) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		p.RelatedIssue = relatedIssue
 
 		return nil, nil
 	}
@@ -84,9 +123,51 @@ func WithRelatedIssue(relatedIssue doyoucompute.Section) doyoucompute.OptionBuil
 //	section := doyoucompute.NewSection("Testing")
 //	section.WriteParagraph().Text("Added unit tests and integration tests")
 //	pullrequest.WithTesting(section)
-func WithTesting(testing doyoucompute.Section) doyoucompute.OptionBuilder[pullRequestProps] {
-	return func(p *pullRequestProps) (doyoucompute.Finalizer[pullRequestProps], error) {
-		p.testing = testing
+func WithTesting(testing doyoucompute.Section) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		p.Testing = testing
+
+		return nil, nil
+	}
+}
+
+// WithProjectInfo applies detected project metadata to the document.
+// The related issue section points at the project's issue tracker.
+//
+// Example:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	pullrequest.WithProjectInfo(info)
+func WithProjectInfo(info metadata.ProjectInfo) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		if !info.HasRepository() {
+			return nil, nil
+		}
+
+		issueTrackerUrl, err := info.Repository.IssuesURL()
+		if err != nil {
+			// Not every forge has a derivable issue tracker, keep the default section
+			return nil, nil
+		}
+
+		p.RelatedIssue = ProjectRelatedIssue(issueTrackerUrl)
+
+		return nil, nil
+	}
+}
+
+// WithSections adds, moves or removes sections once the default sections are in place.
+// Edits naming a section the template does not have fail with sections.ErrUnknownSection.
+//
+// Example:
+//
+//	pullrequest.WithSections(sections.InsertBefore("How I tested", securityImpactSection))
+func WithSections(edits ...sections.Edit) doyoucompute.OptionBuilder[PullRequestProps] {
+	return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		p.Edits = append(p.Edits, edits...)
 
 		return nil, nil
 	}
@@ -117,6 +198,16 @@ func DefaultRelatedIssue() doyoucompute.Section {
 	return section
 }
 
+// ProjectRelatedIssue returns a related issue section that points at the issue tracker.
+func ProjectRelatedIssue(issueTrackerUrl string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("Related issue", func(s *doyoucompute.Section) error {
+		s.WriteComment(fmt.Sprintf("Link to the relevant issue from %s here.", issueTrackerUrl))
+		return nil
+	})
+
+	return section
+}
+
 // DefaultTesting returns the default testing section.
 func DefaultTesting() doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("How I tested", func(s *doyoucompute.Section) error {
@@ -136,13 +227,8 @@ func DefaultTesting() doyoucompute.Section {
 //		pullrequest.WithName("Bug Fix PR"),
 //		pullrequest.WithTesting(customTestingSection),
 //	)
-func New(opts ...doyoucompute.OptionBuilder[pullRequestProps]) (doyoucompute.Document, error) {
-	props := pullRequestProps{
-		name:         DefaultName(),
-		description:  DefaultDescription(),
-		relatedIssue: DefaultRelatedIssue(),
-		testing:      DefaultTesting(),
-	}
+func New(opts ...doyoucompute.OptionBuilder[PullRequestProps]) (doyoucompute.Document, error) {
+	props := Defaults()
 
 	err := doyoucompute.ApplyOptions(&props, opts...)
 	if err != nil {
@@ -150,15 +236,15 @@ func New(opts ...doyoucompute.OptionBuilder[pullRequestProps]) (doyoucompute.Doc
 	}
 
 	// Validate
-	if props.name == "" {
+	if props.Name == "" {
 		return doyoucompute.Document{}, fmt.Errorf("pull request name cannot be empty")
 	}
 
-	return doyoucompute.DocumentFactory(props.name, func(d *doyoucompute.Document) error {
-		d.AddSection(props.description)
-		d.AddSection(props.relatedIssue)
-		d.AddSection(props.testing)
+	return doyoucompute.DocumentFactory(props.Name, func(d *doyoucompute.Document) error {
+		d.AddSection(props.Description)
+		d.AddSection(props.RelatedIssue)
+		d.AddSection(props.Testing)
 
-		return nil
+		return sections.Apply(d, props.Edits...)
 	})
 }
@@ -1,10 +1,16 @@
 package pullrequest
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
 func TestPullRequest(t *testing.T) {
@@ -13,7 +19,7 @@ func TestPullRequest(t *testing.T) {
 
 	tests := []struct {
 		name             string
-		opts             []doyoucompute.OptionBuilder[pullRequestProps]
+		opts             []doyoucompute.OptionBuilder[PullRequestProps]
 		wantErr          bool
 		wantName         string
 		wantContentCount int
@@ -27,7 +33,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with custom name",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithName("Feature: Authentication"),
 			},
 			wantErr:          false,
@@ -36,7 +42,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with custom description",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithDescription(customSection),
 			},
 			wantErr:          false,
@@ -45,7 +51,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with custom related issue",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithRelatedIssue(customSection),
 			},
 			wantErr:          false,
@@ -54,7 +60,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with custom testing",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithTesting(customSection),
 			},
 			wantErr:          false,
@@ -63,7 +69,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with multiple options",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithName("Bug Fix: Memory Leak"),
 				WithDescription(customSection),
 				WithTesting(customSection),
@@ -74,7 +80,7 @@ func TestPullRequest(t *testing.T) {
 		},
 		{
 			name: "with all options",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithName("Complete PR"),
 				WithDescription(customSection),
 				WithRelatedIssue(customSection),
@@ -124,7 +130,7 @@ func TestPullRequest(t *testing.T) {
 func TestPullRequestContent(t *testing.T) {
 	tests := []struct {
 		name            string
-		opts            []doyoucompute.OptionBuilder[pullRequestProps]
+		opts            []doyoucompute.OptionBuilder[PullRequestProps]
 		wantContains    []string
 		wantNotContains []string
 	}{
@@ -139,7 +145,7 @@ func TestPullRequestContent(t *testing.T) {
 		},
 		{
 			name: "custom description replaces default",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithDescription(func() doyoucompute.Section {
 					s := doyoucompute.NewSection("My Description")
 					s.WriteParagraph().Text("Added OAuth2 support")
@@ -156,7 +162,7 @@ func TestPullRequestContent(t *testing.T) {
 		},
 		{
 			name: "custom related issue replaces default",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithRelatedIssue(func() doyoucompute.Section {
 					s := doyoucompute.NewSection("Fixes")
 					s.WriteParagraph().Text("Closes #42")
@@ -173,7 +179,7 @@ func TestPullRequestContent(t *testing.T) {
 		},
 		{
 			name: "custom testing replaces default",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithTesting(func() doyoucompute.Section {
 					s := doyoucompute.NewSection("Testing Details")
 					s.WriteParagraph().Text("Ran unit tests and integration tests")
@@ -188,6 +194,26 @@ func TestPullRequestContent(t *testing.T) {
 				"How did you test these changes?",
 			},
 		},
+		{
+			name: "project info points at issue tracker",
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
+				WithProjectInfo(metadata.ProjectInfo{
+					Repository: repourl.URL{Host: "github.com", Namespace: "user", Name: "project", Forge: repourl.GitHub},
+				}),
+			},
+			wantContains: []string{
+				"Link to the relevant issue from https://github.com/user/project/issues here.",
+			},
+		},
+		{
+			name: "project info without repository keeps default",
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
+				WithProjectInfo(metadata.ProjectInfo{}),
+			},
+			wantContains: []string{
+				"Link to the relevant issue here.",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -270,13 +296,13 @@ func TestDefaultFunctions(t *testing.T) {
 func TestPullRequestValidation(t *testing.T) {
 	tests := []struct {
 		name    string
-		opts    []doyoucompute.OptionBuilder[pullRequestProps]
+		opts    []doyoucompute.OptionBuilder[PullRequestProps]
 		wantErr bool
 		errMsg  string
 	}{
 		{
 			name: "empty name should error",
-			opts: []doyoucompute.OptionBuilder[pullRequestProps]{
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
 				WithName(""),
 			},
 			wantErr: true,
@@ -301,3 +327,125 @@ func TestPullRequestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestSections(t *testing.T) {
+	security := doyoucompute.NewSection("Security impact")
+	security.WriteComment("Does this change affect authentication, secrets or permissions?")
+
+	tests := []struct {
+		name         string
+		opts         []doyoucompute.OptionBuilder[PullRequestProps]
+		wantSections []string
+		wantErr      string
+	}{
+		{
+			name:         "default sections",
+			wantSections: []string{"Description", "Related issue", "How I tested"},
+		},
+		{
+			name: "insert before",
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
+				WithSections(sections.InsertBefore("How I tested", security)),
+			},
+			wantSections: []string{"Description", "Related issue", "Security impact", "How I tested"},
+		},
+		{
+			name: "remove",
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
+				WithSections(sections.Remove("Related issue")),
+			},
+			wantSections: []string{"Description", "How I tested"},
+		},
+		{
+			name: "unknown section",
+			opts: []doyoucompute.OptionBuilder[PullRequestProps]{
+				WithSections(sections.InsertAfter("Screenshots", security)),
+			},
+			wantErr: `"Screenshots" in Pull Request, expected one of Description, Related issue, How I tested`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.opts...)
+			if tt.wantErr != "" {
+				if !errors.Is(err, sections.ErrUnknownSection) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should be sections.ErrUnknownSection containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got := sections.Names(doc); !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("sections.Names() = %q, want %q", got, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestCustomOption(t *testing.T) {
+	defaults := Defaults()
+	appendNote := func(note string) Option {
+		return func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+			if p.Testing.Name != defaults.Testing.Name {
+				return nil, fmt.Errorf("option saw %q, want the default %q", p.Testing.Name, defaults.Testing.Name)
+			}
+
+			p.Testing.WriteParagraph().Text(note)
+
+			return nil, nil
+		}
+	}
+	failing := func(p *PullRequestProps) (doyoucompute.Finalizer[PullRequestProps], error) {
+		return nil, errors.New("custom option failed")
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantContains string
+		wantErr      string
+	}{
+		{
+			name:         "custom option sees the defaults",
+			opts:         []Option{appendNote("A custom note")},
+			wantContains: "A custom note",
+		},
+		{
+			name:         "custom and built-in options mix",
+			opts:         []Option{WithName("Change"), appendNote("Another note")},
+			wantContains: "Another note",
+		},
+		{
+			name:    "custom option errors are returned",
+			opts:    []Option{failing},
+			wantErr: "custom option failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			rendered, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			if !strings.Contains(rendered, tt.wantContains) {
+				t.Errorf("renderer.Render() = %q, should contain %q", rendered, tt.wantContains)
+			}
+		})
+	}
+}
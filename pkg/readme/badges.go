@@ -0,0 +1,352 @@
+package readme
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+// WORKFLOWS_DIR is the directory GitHub Actions workflows are read from.
+const WORKFLOWS_DIR = ".github/workflows"
+
+// CODECOV_FILES are the config files that mark a project as reporting coverage to Codecov.
+var CODECOV_FILES = []string{"codecov.yml", ".codecov.yml", "codecov.yaml", ".codecov.yaml"}
+
+// Badge is an image shown in the badge row under the README title.
+type Badge struct {
+	// Alt is the image's alternative text
+	Alt string
+	// ImageURL is the absolute URL of the badge image
+	ImageURL string
+	// LinkURL is where the badge links to, absolute or relative to the README; optional
+	LinkURL string
+}
+
+// Valid returns an error if the badge is missing its alt text or its URLs are malformed.
+func (b Badge) Valid() error {
+	if b.Alt == "" {
+		return fmt.Errorf("badge alt text cannot be empty")
+	}
+
+	if err := checkAbsoluteURL(b.ImageURL); err != nil {
+		return fmt.Errorf("badge %q has an invalid image url: %w", b.Alt, err)
+	}
+
+	if b.LinkURL == "" {
+		return nil
+	}
+
+	link, err := url.Parse(b.LinkURL)
+	if err != nil {
+		return fmt.Errorf("badge %q has an invalid link url: %w", b.Alt, err)
+	}
+	if link.Scheme != "" || link.Host != "" {
+		if err := checkAbsoluteURL(b.LinkURL); err != nil {
+			return fmt.Errorf("badge %q has an invalid link url: %w", b.Alt, err)
+		}
+	}
+
+	return nil
+}
+
+func checkAbsoluteURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("url cannot be empty")
+	}
+	if strings.ContainsAny(raw, " \t\n") {
+		return fmt.Errorf("url %q cannot contain whitespace", raw)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return fmt.Errorf("url %q must use http or https", raw)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("url %q is missing a host", raw)
+	}
+
+	return nil
+}
+
+// shieldsEscape escapes text for a static shields.io badge path segment.
+func shieldsEscape(text string) string {
+	text = strings.ReplaceAll(text, "-", "--")
+	text = strings.ReplaceAll(text, "_", "__")
+
+	return url.PathEscape(text)
+}
+
+// GoReferenceBadge returns the pkg.go.dev reference badge for the module.
+func GoReferenceBadge(modulePath string) Badge {
+	return Badge{
+		Alt:      "Go Reference",
+		ImageURL: fmt.Sprintf("https://pkg.go.dev/badge/%s.svg", modulePath),
+		LinkURL:  fmt.Sprintf("https://pkg.go.dev/%s", modulePath),
+	}
+}
+
+// GoVersionBadge returns a badge showing the minimum Go version from go.mod.
+func GoVersionBadge(goVersion string) Badge {
+	return Badge{
+		Alt:      "Go version",
+		ImageURL: fmt.Sprintf("https://img.shields.io/badge/go-%s-00ADD8?logo=go", shieldsEscape(goVersion)),
+		LinkURL:  "./go.mod",
+	}
+}
+
+// LicenseBadge returns a badge naming the license by its SPDX identifier.
+func LicenseBadge(license metadata.License) Badge {
+	return Badge{
+		Alt:      "License",
+		ImageURL: fmt.Sprintf("https://img.shields.io/badge/license-%s-blue", shieldsEscape(license.SPDX)),
+		LinkURL:  license.Path,
+	}
+}
+
+// WorkflowBadge returns the status badge of a GitHub Actions workflow.
+// The workflow is the file name under .github/workflows, e.g. "ci.yml".
+func WorkflowBadge(repository repourl.URL, name, workflow string) (Badge, error) {
+	if repository.Forge != repourl.GitHub {
+		return Badge{}, fmt.Errorf("workflow badges are only supported for GitHub repositories, got %s", repository.Forge)
+	}
+
+	if name == "" {
+		name = workflow
+	}
+
+	workflowUrl := fmt.Sprintf("%s/actions/workflows/%s", repository.WebURL(), workflow)
+
+	return Badge{
+		Alt:      name,
+		ImageURL: workflowUrl + "/badge.svg",
+		LinkURL:  workflowUrl,
+	}, nil
+}
+
+// CodecovBadge returns the Codecov coverage badge for the repository.
+func CodecovBadge(repository repourl.URL) (Badge, error) {
+	service := map[repourl.Forge]string{
+		repourl.GitHub:    "gh",
+		repourl.GitLab:    "gl",
+		repourl.Bitbucket: "bb",
+	}[repository.Forge]
+	if service == "" {
+		return Badge{}, fmt.Errorf("codecov does not support repositories hosted on %q", repository.Host)
+	}
+
+	coverageUrl := fmt.Sprintf("https://codecov.io/%s/%s", service, repository.FullPath())
+
+	return Badge{
+		Alt:      "Coverage",
+		ImageURL: coverageUrl + "/graph/badge.svg",
+		LinkURL:  coverageUrl,
+	}, nil
+}
+
+// ReleaseBadge returns a badge showing the latest release of the repository.
+func ReleaseBadge(repository repourl.URL) (Badge, error) {
+	switch repository.Forge {
+	case repourl.GitHub:
+		return Badge{
+			Alt:      "Release",
+			ImageURL: fmt.Sprintf("https://img.shields.io/github/v/release/%s", repository.FullPath()),
+			LinkURL:  repository.WebURL() + "/releases/latest",
+		}, nil
+	case repourl.GitLab:
+		return Badge{
+			Alt:      "Release",
+			ImageURL: fmt.Sprintf("https://img.shields.io/gitlab/v/release/%s", url.PathEscape(repository.FullPath())),
+			LinkURL:  repository.WebURL() + "/-/releases",
+		}, nil
+	}
+
+	return Badge{}, fmt.Errorf("release badges are only supported for GitHub and GitLab repositories, got %s", repository.Forge)
+}
+
+// BadgesFromProject builds the badge row from detected project metadata and the files in fsys.
+// Badges that the project's forge or metadata cannot support are skipped.
+// Workflow badges are added for each file under .github/workflows,
+// and a coverage badge when a Codecov config file is present.
+//
+// Example:
+//
+//	root := os.DirFS(".")
+//	info, err := metadata.Detect(root)
+//	if err != nil {
+//		// handle error
+//	}
+//	badges, err := readme.BadgesFromProject(info, root)
+func BadgesFromProject(info metadata.ProjectInfo, fsys fs.FS) ([]Badge, error) {
+	var badges []Badge
+
+	if info.ModulePath != "" {
+		badges = append(badges, GoReferenceBadge(info.ModulePath))
+	}
+
+	if info.GoVersion != "" {
+		badges = append(badges, GoVersionBadge(info.GoVersion))
+	}
+
+	if info.License.Known() {
+		badges = append(badges, LicenseBadge(info.License))
+	}
+
+	if !info.HasRepository() {
+		return badges, nil
+	}
+
+	if info.Repository.Forge == repourl.GitHub {
+		workflows, err := workflowBadges(info.Repository, fsys)
+		if err != nil {
+			return nil, err
+		}
+
+		badges = append(badges, workflows...)
+	}
+
+	for _, name := range CODECOV_FILES {
+		if _, err := fs.Stat(fsys, name); err != nil {
+			continue
+		}
+
+		if badge, err := CodecovBadge(info.Repository); err == nil {
+			badges = append(badges, badge)
+		}
+
+		break
+	}
+
+	if badge, err := ReleaseBadge(info.Repository); err == nil {
+		badges = append(badges, badge)
+	}
+
+	return badges, nil
+}
+
+// workflowBadges returns a badge for each workflow file, sorted by file name.
+func workflowBadges(repository repourl.URL, fsys fs.FS) ([]Badge, error) {
+	entries, err := fs.ReadDir(fsys, WORKFLOWS_DIR)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("could not read workflows: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var badges []Badge
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		name, err := workflowName(fsys, path.Join(WORKFLOWS_DIR, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		badge, err := WorkflowBadge(repository, name, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		badges = append(badges, badge)
+	}
+
+	return badges, nil
+}
+
+// workflowName reads the top-level name of a workflow file, if it declares one.
+func workflowName(fsys fs.FS, name string) (string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return "", fmt.Errorf("could not read workflow %s: %w", name, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "name:") {
+			continue
+		}
+
+		value := strings.TrimSpace(strings.TrimPrefix(line, "name:"))
+		return strings.Trim(value, `"'`), nil
+	}
+
+	return "", scanner.Err()
+}
+
+// WithBadges adds badges to the badge row shown between the title and the introduction.
+// Badges are appended in order, after any added by earlier options.
+//
+// Example:
+//
+//	readme.WithBadges(readme.Badge{
+//		Alt:      "Chat",
+//		ImageURL: "https://img.shields.io/badge/chat-discord-5865F2",
+//		LinkURL:  "https://discord.gg/example",
+//	})
+func WithBadges(badges ...Badge) doyoucompute.OptionBuilder[ReadmeProps] {
+	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		for _, badge := range badges {
+			if err := badge.Valid(); err != nil {
+				return nil, err
+			}
+		}
+
+		p.Badges = append(p.Badges, badges...)
+
+		return nil, nil
+	}
+}
+
+// WithProjectBadges adds the badges built by BadgesFromProject to the badge row.
+//
+// Example:
+//
+//	readme.WithProjectBadges(info, os.DirFS("."))
+func WithProjectBadges(info metadata.ProjectInfo, fsys fs.FS) doyoucompute.OptionBuilder[ReadmeProps] {
+	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		badges, err := BadgesFromProject(info, fsys)
+		if err != nil {
+			return nil, err
+		}
+
+		return WithBadges(badges...)(p)
+	}
+}
+
+// BadgeRow returns the paragraph rendering the badges side by side.
+func BadgeRow(badges []Badge) *doyoucompute.Paragraph {
+	row := doyoucompute.NewParagraph()
+
+	for _, badge := range badges {
+		image := fmt.Sprintf("![%s](%s)", badge.Alt, badge.ImageURL)
+		if badge.LinkURL == "" {
+			row.Text(image)
+			continue
+		}
+
+		row.Link(image, badge.LinkURL)
+	}
+
+	return row
+}
@@ -0,0 +1,283 @@
+package readme
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+func mustParse(t *testing.T, raw string) repourl.URL {
+	t.Helper()
+
+	repository, err := repourl.Parse(raw)
+	if err != nil {
+		t.Fatalf("repourl.Parse() error = %v", err)
+	}
+
+	return repository
+}
+
+func TestBadgeValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		badge   Badge
+		wantErr string
+	}{
+		{
+			name:  "absolute link",
+			badge: Badge{Alt: "CI", ImageURL: "https://example.com/badge.svg", LinkURL: "https://example.com"},
+		},
+		{
+			name:  "relative link",
+			badge: Badge{Alt: "License", ImageURL: "https://example.com/badge.svg", LinkURL: "./LICENSE"},
+		},
+		{
+			name:  "no link",
+			badge: Badge{Alt: "Status", ImageURL: "https://example.com/badge.svg"},
+		},
+		{
+			name:    "missing alt",
+			badge:   Badge{ImageURL: "https://example.com/badge.svg"},
+			wantErr: "alt text cannot be empty",
+		},
+		{
+			name:    "relative image",
+			badge:   Badge{Alt: "CI", ImageURL: "./badge.svg"},
+			wantErr: "must use http or https",
+		},
+		{
+			name:    "image without host",
+			badge:   Badge{Alt: "CI", ImageURL: "https:///badge.svg"},
+			wantErr: "missing a host",
+		},
+		{
+			name:    "image with whitespace",
+			badge:   Badge{Alt: "CI", ImageURL: "https://example.com/my badge.svg"},
+			wantErr: "cannot contain whitespace",
+		},
+		{
+			name:    "link with unsupported scheme",
+			badge:   Badge{Alt: "CI", ImageURL: "https://example.com/badge.svg", LinkURL: "ftp://example.com"},
+			wantErr: "invalid link url",
+		},
+		{
+			name:    "malformed link",
+			badge:   Badge{Alt: "CI", ImageURL: "https://example.com/badge.svg", LinkURL: "https://exa mple.com/%zz"},
+			wantErr: "invalid link url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.badge.Valid()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Valid() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Valid() error = %v, should contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBadgesFromProject(t *testing.T) {
+	workflows := fstest.MapFS{
+		".github/workflows/release.yaml": {Data: []byte("on: push\n")},
+		".github/workflows/ci.yml":       {Data: []byte("name: \"Build and test\"\non: push\njobs:\n  test:\n    name: unit\n")},
+		".github/workflows/README.md":    {Data: []byte("# Workflows\n")},
+		".codecov.yml":                   {Data: []byte("coverage:\n")},
+	}
+
+	tests := []struct {
+		name     string
+		info     metadata.ProjectInfo
+		fsys     fstest.MapFS
+		wantAlts []string
+		wantUrls []string
+	}{
+		{
+			name:     "no metadata",
+			info:     metadata.ProjectInfo{},
+			fsys:     fstest.MapFS{},
+			wantAlts: []string{},
+		},
+		{
+			name: "module without repository",
+			info: metadata.ProjectInfo{
+				ModulePath: "example.com/project",
+				GoVersion:  "1.23.7",
+				License:    metadata.License{SPDX: "Apache-2.0", Name: "Apache 2.0", Path: "./LICENSE"},
+			},
+			fsys:     workflows,
+			wantAlts: []string{"Go Reference", "Go version", "License"},
+			wantUrls: []string{
+				"https://pkg.go.dev/badge/example.com/project.svg",
+				"https://img.shields.io/badge/go-1.23.7-00ADD8?logo=go",
+				"https://img.shields.io/badge/license-Apache--2.0-blue",
+			},
+		},
+		{
+			name: "github repository with workflows and coverage",
+			info: metadata.ProjectInfo{
+				ModulePath: "github.com/user/project",
+				Repository: mustParse(t, "https://github.com/user/project"),
+			},
+			fsys:     workflows,
+			wantAlts: []string{"Go Reference", "Build and test", "release.yaml", "Coverage", "Release"},
+			wantUrls: []string{
+				"https://github.com/user/project/actions/workflows/ci.yml/badge.svg",
+				"https://github.com/user/project/actions/workflows/release.yaml/badge.svg",
+				"https://codecov.io/gh/user/project/graph/badge.svg",
+				"https://img.shields.io/github/v/release/user/project",
+			},
+		},
+		{
+			name: "gitlab repository skips workflows",
+			info: metadata.ProjectInfo{
+				Repository: mustParse(t, "https://gitlab.com/group/sub/project"),
+			},
+			fsys:     workflows,
+			wantAlts: []string{"Coverage", "Release"},
+			wantUrls: []string{
+				"https://codecov.io/gl/group/sub/project/graph/badge.svg",
+				"https://img.shields.io/gitlab/v/release/group%2Fsub%2Fproject",
+			},
+		},
+		{
+			name: "github repository without workflows",
+			info: metadata.ProjectInfo{
+				Repository: mustParse(t, "https://github.com/user/project"),
+			},
+			fsys:     fstest.MapFS{},
+			wantAlts: []string{"Release"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			badges, err := BadgesFromProject(tt.info, tt.fsys)
+			if err != nil {
+				t.Fatalf("BadgesFromProject() error = %v", err)
+			}
+
+			alts := make([]string, len(badges))
+			images := make([]string, len(badges))
+			for idx, badge := range badges {
+				alts[idx] = badge.Alt
+				images[idx] = badge.ImageURL
+
+				if err := badge.Valid(); err != nil {
+					t.Errorf("BadgesFromProject() returned invalid badge: %v", err)
+				}
+			}
+
+			if strings.Join(alts, ",") != strings.Join(tt.wantAlts, ",") {
+				t.Errorf("BadgesFromProject() alts = %v, want %v", alts, tt.wantAlts)
+			}
+
+			for _, want := range tt.wantUrls {
+				if !strings.Contains(strings.Join(images, " "), want) {
+					t.Errorf("BadgesFromProject() missing image url %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestBadgeErrors(t *testing.T) {
+	bitbucket := mustParse(t, "https://bitbucket.org/team/project")
+	unknown := mustParse(t, "https://git.example.com/team/project")
+
+	if _, err := WorkflowBadge(bitbucket, "", "ci.yml"); err == nil {
+		t.Error("WorkflowBadge() expected error for Bitbucket")
+	}
+	if _, err := ReleaseBadge(bitbucket); err == nil {
+		t.Error("ReleaseBadge() expected error for Bitbucket")
+	}
+	if _, err := CodecovBadge(unknown); err == nil {
+		t.Error("CodecovBadge() expected error for unknown forge")
+	}
+}
+
+func TestReadmeBadgeRow(t *testing.T) {
+	introParagraph := doyoucompute.NewParagraph().Text("This is a test project")
+	featuresSection := doyoucompute.NewSection("Features")
+	quickStartSection := doyoucompute.NewSection("Quick Start")
+
+	props := ReadmeProps{
+		Name:       "Test Project",
+		Intro:      *introParagraph,
+		Features:   featuresSection,
+		QuickStart: quickStartSection,
+	}
+
+	tests := []struct {
+		name    string
+		opts    []doyoucompute.OptionBuilder[ReadmeProps]
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no badges by default",
+			want: "# Test Project\n\nThis is a test project",
+		},
+		{
+			name: "badges between title and intro",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithProjectBadges(metadata.ProjectInfo{ModulePath: "example.com/project"}, fstest.MapFS{}),
+				WithBadges(Badge{Alt: "Chat", ImageURL: "https://img.shields.io/badge/chat-discord-5865F2"}),
+			},
+			want: "# Test Project\n\n[![Go Reference](https://pkg.go.dev/badge/example.com/project.svg)](https://pkg.go.dev/example.com/project) ![Chat](https://img.shields.io/badge/chat-discord-5865F2)\n\nThis is a test project",
+		},
+		{
+			name: "invalid custom badge",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithBadges(Badge{Alt: "Broken", ImageURL: "badge.svg"}),
+			},
+			wantErr: "badge \"Broken\" has an invalid image url",
+		},
+		{
+			name: "invalid badge set by a custom option",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+					p.Badges = append(p.Badges, Badge{ImageURL: "https://example.com/badge.svg"})
+
+					return nil, nil
+				},
+			},
+			wantErr: "alt text cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(props, nil, tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			if !strings.Contains(rendered, tt.want) {
+				t.Errorf("renderer.Render() = %q, should contain %q", rendered, tt.want)
+			}
+		})
+	}
+}
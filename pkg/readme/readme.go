@@ -17,6 +17,15 @@
 //		// handle error
 //	}
 //
+// With a badge row built from project metadata:
+//
+//	root := os.DirFS(".")
+//	info, err := metadata.Detect(root)
+//	if err != nil {
+//		// handle error
+//	}
+//	doc, err := readme.New(props, nil, readme.WithProjectBadges(info, root))
+//
 // With additional sections:
 //
 //	doc, err := readme.New(
@@ -24,17 +33,35 @@
 //		[]doyoucompute.Section{usageSection, examplesSection},
 //		readme.WithName("Project README"),
 //	)
+//
+// Writing your own option, which runs alongside the built-in ones in the order given:
+//
+//	func WithSponsorBadge(url string) readme.Option {
+//		return func(p *readme.ReadmeProps) (doyoucompute.Finalizer[readme.ReadmeProps], error) {
+//			p.Badges = append(p.Badges, readme.Badge{Alt: "Sponsor", ImageURL: url})
+//			return nil, nil
+//		}
+//	}
 package readme
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/toc"
 )
 
+// DEFAULT_PATH is the conventional location of the README, at the repository root.
+const DEFAULT_PATH = "README.md"
+
 // ReadmeProps defines the required and optional properties for a README document.
 // Name, Intro, Features, and QuickStart must be provided when creating a new document.
-// The contributing and license fields are automatically set to defaults.
+// The contributing and license sections default to DefaultContributing and DefaultLicense
+// when left empty. Options then change the props in the order given, so options outside
+// this package can be written as any doyoucompute.OptionBuilder[ReadmeProps].
 type ReadmeProps struct {
 	// Document name
 	Name string
@@ -43,11 +70,22 @@ type ReadmeProps struct {
 	// Features section
 	Features doyoucompute.Section
 	// Quick start section
-	QuickStart   doyoucompute.Section
-	contributing doyoucompute.Section
-	license      doyoucompute.Section
+	QuickStart doyoucompute.Section
+	// Badges shown between the title and the introduction
+	Badges []Badge
+	// Depth of the table of contents, or 0 for none
+	TableOfContents int
+	// Contributing section
+	Contributing doyoucompute.Section
+	// License section
+	License doyoucompute.Section
+	// Section edits applied once the sections above are in place
+	Edits []sections.Edit
 }
 
+// Option changes the props of a README before it is rendered.
+type Option = doyoucompute.OptionBuilder[ReadmeProps]
+
 // WithName overrides the document name.
 //
 // Example:
@@ -61,24 +99,44 @@ func WithName(name string) doyoucompute.OptionBuilder[ReadmeProps] {
 	}
 }
 
+// WithLicense overrides the license section to name the license.
+//
+// Example:
+//
+//	readme.WithLicense("MIT", "./LICENSE")
 func WithLicense(name, path string) doyoucompute.OptionBuilder[ReadmeProps] {
 	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
-		section, _ := doyoucompute.SectionFactory("License", func(s *doyoucompute.Section) error {
-			s.WriteIntro().
-				Text(fmt.Sprintf("%s License -", name)).
-				Text("see").
-				Link("LICENSE", path).
-				Text("for details.")
+		p.License = NamedLicense(name, path)
 
-			return nil
-		})
+		return nil, nil
+	}
+}
 
-		p.license = section
+// WithProjectInfo applies detected project metadata to the document.
+// When a license was identified the license section names it.
+//
+// Example:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	readme.WithProjectInfo(info)
+func WithProjectInfo(info metadata.ProjectInfo) doyoucompute.OptionBuilder[ReadmeProps] {
+	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		if info.License.Known() {
+			p.License = NamedLicense(info.License.Name, info.License.Path)
+		}
 
 		return nil, nil
 	}
 }
 
+// WithContributing overrides the path the contributing section links to.
+//
+// Example:
+//
+//	readme.WithContributing("./docs/CONTRIBUTING.md")
 func WithContributing(path string) doyoucompute.OptionBuilder[ReadmeProps] {
 	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
 		section, _ := doyoucompute.SectionFactory("Contributing", func(s *doyoucompute.Section) error {
@@ -90,12 +148,64 @@ func WithContributing(path string) doyoucompute.OptionBuilder[ReadmeProps] {
 			return nil
 		})
 
-		p.contributing = section
+		p.Contributing = section
 
 		return nil, nil
 	}
 }
 
+// WithTableOfContents inserts a table of contents after the introduction,
+// listing sections down to depth, where 1 lists only top-level sections.
+//
+// Example:
+//
+//	readme.WithTableOfContents(2)
+func WithTableOfContents(depth int) doyoucompute.OptionBuilder[ReadmeProps] {
+	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		if depth < 1 {
+			return nil, fmt.Errorf("table of contents depth must be at least 1, got %d", depth)
+		}
+
+		p.TableOfContents = depth
+
+		return nil, nil
+	}
+}
+
+// WithSections adds, moves or removes sections once the default sections are in place.
+// Unlike additionalSections, which always sit between the quick start and contributing
+// sections, edits can place a section anywhere. Edits naming a section the README does
+// not have fail with sections.ErrUnknownSection.
+//
+// Example:
+//
+//	readme.WithSections(
+//		sections.InsertBefore("Features", motivationSection),
+//		sections.Remove("Contributing"),
+//	)
+func WithSections(edits ...sections.Edit) doyoucompute.OptionBuilder[ReadmeProps] {
+	return func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		p.Edits = append(p.Edits, edits...)
+
+		return nil, nil
+	}
+}
+
+// NamedLicense returns a license section that names the license.
+func NamedLicense(name, path string) doyoucompute.Section {
+	section, _ := doyoucompute.SectionFactory("License", func(s *doyoucompute.Section) error {
+		s.WriteIntro().
+			Text(fmt.Sprintf("%s License -", name)).
+			Text("see").
+			Link("LICENSE", path).
+			Text("for details.")
+
+		return nil
+	})
+
+	return section
+}
+
 // DefaultContributing returns the default contributing section.
 func DefaultContributing() doyoucompute.Section {
 	section, _ := doyoucompute.SectionFactory("Contributing", func(s *doyoucompute.Section) error {
@@ -153,13 +263,16 @@ func New(props ReadmeProps, additionalSections []doyoucompute.Section, opts ...d
 		return doyoucompute.Document{}, fmt.Errorf("quick start section is required")
 	}
 
-	sProps := ReadmeProps{
-		Name:         props.Name,
-		Intro:        props.Intro,
-		Features:     props.Features,
-		QuickStart:   props.QuickStart,
-		contributing: DefaultContributing(),
-		license:      DefaultLicense(),
+	// Copy the slices so options appending to them cannot write into the caller's props
+	sProps := props
+	sProps.Badges = slices.Clone(props.Badges)
+	sProps.Edits = slices.Clone(props.Edits)
+
+	if sProps.Contributing.Name == "" {
+		sProps.Contributing = DefaultContributing()
+	}
+	if sProps.License.Name == "" {
+		sProps.License = DefaultLicense()
 	}
 
 	err := doyoucompute.ApplyOptions(&sProps, opts...)
@@ -171,9 +284,21 @@ func New(props ReadmeProps, additionalSections []doyoucompute.Section, opts ...d
 	if sProps.Name == "" {
 		return doyoucompute.Document{}, fmt.Errorf("readme name cannot be empty after applying options")
 	}
+	// Badges may be set in props or by custom options without going through WithBadges
+	for _, badge := range sProps.Badges {
+		if err := badge.Valid(); err != nil {
+			return doyoucompute.Document{}, err
+		}
+	}
 
 	return doyoucompute.DocumentFactory(sProps.Name, func(d *doyoucompute.Document) error {
 		d.AddIntro(&sProps.Intro)
+
+		// Badges sit between the title and the introduction
+		if len(sProps.Badges) > 0 {
+			d.AddIntro(BadgeRow(sProps.Badges))
+		}
+
 		d.AddSection(sProps.Features)
 		d.AddSection(sProps.QuickStart)
 
@@ -182,8 +307,17 @@ func New(props ReadmeProps, additionalSections []doyoucompute.Section, opts ...d
 		}
 
 		// Always put contributing and license last in the document
-		d.AddSection(sProps.contributing)
-		d.AddSection(sProps.license)
+		d.AddSection(sProps.Contributing)
+		d.AddSection(sProps.License)
+
+		// Edit before the table of contents is built, so it lists the final sections
+		if err := sections.Apply(d, sProps.Edits...); err != nil {
+			return err
+		}
+
+		if sProps.TableOfContents > 0 {
+			return toc.Insert(d, sProps.TableOfContents)
+		}
 
 		return nil
 	})
@@ -1,10 +1,15 @@
 package readme
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
 )
 
 func TestReadme(t *testing.T) {
@@ -233,6 +238,53 @@ func TestReadmeContent(t *testing.T) {
 				"License",
 			},
 		},
+		{
+			name: "license option names the license",
+			props: ReadmeProps{
+				Name:       "Test Project",
+				Intro:      *introParagraph,
+				Features:   featuresSection,
+				QuickStart: quickStartSection,
+			},
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithLicense("Apache 2.0", "./LICENSE.txt"),
+			},
+			wantContains: []string{
+				"Apache 2.0 License - see [LICENSE](./LICENSE.txt) for details.",
+			},
+		},
+		{
+			name: "project info names the detected license",
+			props: ReadmeProps{
+				Name:       "Test Project",
+				Intro:      *introParagraph,
+				Features:   featuresSection,
+				QuickStart: quickStartSection,
+			},
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithProjectInfo(metadata.ProjectInfo{
+					License: metadata.License{SPDX: "MIT", Name: "MIT", Path: "./LICENSE"},
+				}),
+			},
+			wantContains: []string{
+				"MIT License - see [LICENSE](./LICENSE) for details.",
+			},
+		},
+		{
+			name: "project info without license keeps default",
+			props: ReadmeProps{
+				Name:       "Test Project",
+				Intro:      *introParagraph,
+				Features:   featuresSection,
+				QuickStart: quickStartSection,
+			},
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithProjectInfo(metadata.ProjectInfo{}),
+			},
+			wantContains: []string{
+				"See [LICENSE](./LICENSE) for details.",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -469,3 +521,238 @@ func TestReadmeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestReadmeTableOfContents(t *testing.T) {
+	introParagraph := doyoucompute.NewParagraph().Text("This is a test project")
+	featuresSection := doyoucompute.NewSection("Features")
+	quickStartSection := doyoucompute.NewSection("Quick Start")
+	quickStartSection.CreateSection("Install")
+
+	props := ReadmeProps{
+		Name:       "Test Project",
+		Intro:      *introParagraph,
+		Features:   featuresSection,
+		QuickStart: quickStartSection,
+	}
+
+	tests := []struct {
+		name            string
+		opts            []doyoucompute.OptionBuilder[ReadmeProps]
+		wantContains    []string
+		wantNotContains []string
+		wantErr         string
+	}{
+		{
+			name:            "no table of contents by default",
+			wantNotContains: []string{"## Table of contents"},
+		},
+		{
+			name: "top level sections after intro and badges",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithBadges(Badge{Alt: "CI", ImageURL: "https://example.com/ci.svg"}),
+				WithTableOfContents(1),
+			},
+			wantContains: []string{
+				"![CI](https://example.com/ci.svg)\n\nThis is a test project\n\n## Table of contents\n\n- [Features](#features)\n- [Quick Start](#quick-start)\n- [Contributing](#contributing)\n- [License](#license)",
+			},
+			wantNotContains: []string{"(#install)"},
+		},
+		{
+			name: "nested sections",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithTableOfContents(2),
+			},
+			wantContains: []string{"- [Quick Start](#quick-start)\n  - [Install](#install)"},
+		},
+		{
+			name: "invalid depth",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithTableOfContents(0),
+			},
+			wantErr: "depth must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(props, nil, tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			renderer := doyoucompute.NewMarkdownRenderer()
+			rendered, err := renderer.Render(&doc)
+			if err != nil {
+				t.Fatalf("renderer.Render() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("renderer.Render() = %q, should contain %q", rendered, want)
+				}
+			}
+
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(rendered, notWant) {
+					t.Errorf("renderer.Render() contains unexpected content: %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestReadmeSections(t *testing.T) {
+	props := ReadmeProps{
+		Name:       "Test Project",
+		Intro:      *doyoucompute.NewParagraph().Text("This is a test project"),
+		Features:   doyoucompute.NewSection("Features"),
+		QuickStart: doyoucompute.NewSection("Quick Start"),
+	}
+
+	motivation := doyoucompute.NewSection("Motivation")
+	motivation.WriteParagraph().Text("Why this project exists")
+
+	tests := []struct {
+		name         string
+		opts         []doyoucompute.OptionBuilder[ReadmeProps]
+		wantSections []string
+		wantErr      string
+	}{
+		{
+			name:         "default sections",
+			wantSections: []string{"Features", "Quick Start", "Contributing", "License"},
+		},
+		{
+			name: "insert before the first section and remove",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithSections(
+					sections.InsertBefore("Features", motivation),
+					sections.Remove("Contributing"),
+				),
+			},
+			wantSections: []string{"Motivation", "Features", "Quick Start", "License"},
+		},
+		{
+			name: "table of contents lists edited sections",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithSections(sections.Append(motivation)),
+				WithTableOfContents(1),
+			},
+			wantSections: []string{"Table of contents", "Features", "Quick Start", "Contributing", "License", "Motivation"},
+		},
+		{
+			name: "unknown section",
+			opts: []doyoucompute.OptionBuilder[ReadmeProps]{
+				WithSections(sections.Remove("Usage")),
+			},
+			wantErr: `"Usage" in Test Project`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(props, nil, tt.opts...)
+			if tt.wantErr != "" {
+				if !errors.Is(err, sections.ErrUnknownSection) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, should be sections.ErrUnknownSection containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got := sections.Names(doc); !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("sections.Names() = %q, want %q", got, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestReadmeSectionsBuildTwice(t *testing.T) {
+	features := doyoucompute.NewSection("Features")
+	details := doyoucompute.NewSection("Details")
+	details.AddSection(doyoucompute.NewSection("Deep"))
+	features.AddSection(details)
+
+	props := ReadmeProps{
+		Name:       "Test Project",
+		Intro:      *doyoucompute.NewParagraph().Text("This is a test project"),
+		Features:   features,
+		QuickStart: doyoucompute.NewSection("Quick Start"),
+	}
+
+	for range 2 {
+		doc, err := New(props, nil, WithSections(sections.Remove("Deep")))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		if got := sections.Names(doc); slices.Contains(got, "Deep") {
+			t.Errorf("sections.Names() = %q, should not contain Deep", got)
+		}
+	}
+
+	doc, err := New(props, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got := sections.Names(doc); !slices.Contains(got, "Deep") {
+		t.Errorf("sections.Names() = %q, should still contain Deep", got)
+	}
+}
+
+func TestReadmePropsFields(t *testing.T) {
+	ci := Badge{Alt: "CI", ImageURL: "https://example.com/ci.svg"}
+	docs := Badge{Alt: "Docs", ImageURL: "https://example.com/docs.svg"}
+
+	props := ReadmeProps{
+		Name:            "Test Project",
+		Intro:           *doyoucompute.NewParagraph().Text("This is a test project"),
+		Features:        doyoucompute.NewSection("Features"),
+		QuickStart:      doyoucompute.NewSection("Quick Start"),
+		Badges:          make([]Badge, 1, 2),
+		TableOfContents: 1,
+		License:         NamedLicense("MIT", "./LICENSE"),
+	}
+	props.Badges[0] = ci
+
+	// A custom option reading and extending the props
+	withDocsBadge := func(p *ReadmeProps) (doyoucompute.Finalizer[ReadmeProps], error) {
+		p.Badges = append(p.Badges, docs)
+
+		return nil, nil
+	}
+
+	doc, err := New(props, nil, withDocsBadge)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	rendered, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	if err != nil {
+		t.Fatalf("renderer.Render() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"![CI](https://example.com/ci.svg) ![Docs](https://example.com/docs.svg)",
+		"## Table of contents",
+		"MIT License - see [LICENSE](./LICENSE)",
+		"See [CONTRIBUTING](./CONTRIBUTING.md)",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("renderer.Render() = %q, should contain %q", rendered, want)
+		}
+	}
+
+	if got := props.Badges[:cap(props.Badges)]; got[1] != (Badge{}) {
+		t.Errorf("New() wrote %v into the caller's badges", got[1])
+	}
+}
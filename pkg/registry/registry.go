@@ -0,0 +1,212 @@
+// Package registry collects generated documents with the paths they are rendered to.
+//
+// Each template package declares its conventional path as DEFAULT_PATH, so a
+// project can render, compare and register its whole set of documents at once
+// instead of naming every document and path by hand.
+//
+// Basic usage:
+//
+//	docs, err := registry.New(
+//		registry.Entry{Path: readme.DEFAULT_PATH, Document: readmeDoc},
+//		registry.Entry{Path: contributing.DEFAULT_PATH, Document: contributingDoc},
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	svc, err := doyoucompute.DefaultService()
+//	if err != nil {
+//		// handle error
+//	}
+//	if err := docs.RenderAll(svc); err != nil {
+//		// handle error
+//	}
+//
+// Registering the documents with a doyoucompute app:
+//
+//	cli := app.Default()
+//	docs.Register(cli)
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/pullrequest"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+)
+
+// Entry is a document and the path it is rendered to.
+type Entry struct {
+	// Path is relative to the repository root, using forward slashes
+	Path string
+	// Document is the document rendered to Path
+	Document doyoucompute.Document
+}
+
+// Valid returns an error if the entry cannot be rendered.
+func (e Entry) Valid() error {
+	if e.Path == "" {
+		return errors.New("entry path cannot be empty")
+	}
+	if clean := path.Clean(e.Path); !fs.ValidPath(clean) || clean == "." {
+		return fmt.Errorf("entry path %q must be a relative path inside the repository", e.Path)
+	}
+	if e.Document.Name == "" {
+		return fmt.Errorf("document for %s must have a name", e.Path)
+	}
+
+	return nil
+}
+
+// ReadMe returns an entry rendering doc to readme.DEFAULT_PATH.
+func ReadMe(doc doyoucompute.Document) Entry {
+	return Entry{Path: readme.DEFAULT_PATH, Document: doc}
+}
+
+// Contributing returns an entry rendering doc to contributing.DEFAULT_PATH.
+func Contributing(doc doyoucompute.Document) Entry {
+	return Entry{Path: contributing.DEFAULT_PATH, Document: doc}
+}
+
+// PullRequest returns an entry rendering doc to pullrequest.DEFAULT_PATH.
+func PullRequest(doc doyoucompute.Document) Entry {
+	return Entry{Path: pullrequest.DEFAULT_PATH, Document: doc}
+}
+
+// BugReport returns an entry rendering doc to bugreport.DEFAULT_PATH.
+func BugReport(doc doyoucompute.Document) Entry {
+	return Entry{Path: bugreport.DEFAULT_PATH, Document: doc}
+}
+
+// Registry is an ordered set of entries with unique paths and document names.
+type Registry struct {
+	entries []Entry
+}
+
+// New returns a registry containing the entries, in order.
+func New(entries ...Entry) (*Registry, error) {
+	registry := &Registry{}
+
+	for _, entry := range entries {
+		if err := registry.Add(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Add appends an entry to the registry.
+// Paths must be unique, and so must document names, since the doyoucompute app
+// looks documents up by name.
+func (r *Registry) Add(entry Entry) error {
+	if err := entry.Valid(); err != nil {
+		return err
+	}
+
+	entry.Path = path.Clean(entry.Path)
+
+	for _, existing := range r.entries {
+		if existing.Path == entry.Path {
+			return fmt.Errorf("a document is already registered at %s", entry.Path)
+		}
+		if existing.Document.Name == entry.Document.Name {
+			return fmt.Errorf("a document named %q is already registered at %s", entry.Document.Name, existing.Path)
+		}
+	}
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+// Entries returns the registered entries in the order they were added.
+func (r *Registry) Entries() []Entry {
+	return append([]Entry{}, r.entries...)
+}
+
+// Registerer is implemented by the doyoucompute app.
+type Registerer interface {
+	Register(document doyoucompute.Document)
+}
+
+// Register adds every document to app, so each can be rendered or run by name.
+func (r *Registry) Register(app Registerer) {
+	for _, entry := range r.entries {
+		app.Register(entry.Document)
+	}
+}
+
+// Service renders documents to files and compares them with files on disk.
+// It is implemented by *doyoucompute.Service.
+type Service interface {
+	RenderFile(document *doyoucompute.Document, outpath string) error
+	CompareFile(document *doyoucompute.Document, pathToFile string) (doyoucompute.ComparisonResult, error)
+}
+
+// RenderAll renders every document to its path, stopping at the first failure.
+// Parent directories must already exist.
+func (r *Registry) RenderAll(svc Service) error {
+	for _, entry := range r.entries {
+		document := entry.Document
+
+		if err := svc.RenderFile(&document, entry.Path); err != nil {
+			return fmt.Errorf("could not render %s to %s: %w", document.Name, entry.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Comparison is the result of comparing a document with its rendered file.
+type Comparison struct {
+	// Entry is the compared entry
+	Entry Entry
+	// Matches reports whether the file is up to date
+	Matches bool
+	// Missing reports whether the file has not been rendered yet
+	Missing bool
+}
+
+// CompareAll compares every document with the file at its path.
+// Missing files are reported as comparisons rather than errors, so a single
+// call lists every document that needs rendering.
+func (r *Registry) CompareAll(svc Service) ([]Comparison, error) {
+	comparisons := make([]Comparison, 0, len(r.entries))
+
+	for _, entry := range r.entries {
+		document := entry.Document
+
+		result, err := svc.CompareFile(&document, entry.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			comparisons = append(comparisons, Comparison{Entry: entry, Missing: true})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not compare %s with %s: %w", document.Name, entry.Path, err)
+		}
+
+		comparisons = append(comparisons, Comparison{Entry: entry, Matches: result.Matches})
+	}
+
+	return comparisons, nil
+}
+
+// Stale returns the comparisons whose files are missing or out of date.
+func Stale(comparisons []Comparison) []Comparison {
+	var stale []Comparison
+
+	for _, comparison := range comparisons {
+		if !comparison.Matches {
+			stale = append(stale, comparison)
+		}
+	}
+
+	return stale
+}
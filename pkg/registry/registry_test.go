@@ -0,0 +1,230 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+func document(name string) doyoucompute.Document {
+	doc, _ := doyoucompute.DocumentFactory(name, func(d *doyoucompute.Document) error {
+		d.WriteIntro().Text("Content of " + name)
+		return nil
+	})
+
+	return doc
+}
+
+type fakeApp struct {
+	registered []string
+}
+
+func (f *fakeApp) Register(document doyoucompute.Document) {
+	f.registered = append(f.registered, document.Name)
+}
+
+// fakeService stores rendered documents by path instead of writing files.
+type fakeService struct {
+	files   map[string]string
+	failing string
+}
+
+func (f *fakeService) RenderFile(document *doyoucompute.Document, outpath string) error {
+	if outpath == f.failing {
+		return errors.New("disk full")
+	}
+
+	f.files[outpath] = document.Name
+
+	return nil
+}
+
+func (f *fakeService) CompareFile(document *doyoucompute.Document, pathToFile string) (doyoucompute.ComparisonResult, error) {
+	if pathToFile == f.failing {
+		return doyoucompute.ComparisonResult{}, errors.New("permission denied")
+	}
+
+	content, ok := f.files[pathToFile]
+	if !ok {
+		return doyoucompute.ComparisonResult{}, fmt.Errorf("open %s: %w", pathToFile, fs.ErrNotExist)
+	}
+
+	return doyoucompute.ComparisonResult{Matches: content == document.Name}, nil
+}
+
+func TestDefaultPaths(t *testing.T) {
+	doc := document("Doc")
+
+	tests := []struct {
+		name  string
+		entry Entry
+		want  string
+	}{
+		{name: "readme", entry: ReadMe(doc), want: "README.md"},
+		{name: "contributing", entry: Contributing(doc), want: "CONTRIBUTING.md"},
+		{name: "pull request", entry: PullRequest(doc), want: ".github/PULL_REQUEST_TEMPLATE.md"},
+		{name: "bug report", entry: BugReport(doc), want: ".github/ISSUE_TEMPLATE/bug_report.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.entry.Path != tt.want {
+				t.Errorf("Path = %q, want %q", tt.entry.Path, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "entries keep their order",
+			entries: []Entry{ReadMe(document("Readme")), BugReport(document("Bug")), {Path: "./docs/guide.md", Document: document("Guide")}},
+			want:    []string{"README.md", ".github/ISSUE_TEMPLATE/bug_report.md", "docs/guide.md"},
+		},
+		{
+			name:    "empty path",
+			entries: []Entry{{Document: document("Readme")}},
+			wantErr: "entry path cannot be empty",
+		},
+		{
+			name:    "absolute path",
+			entries: []Entry{{Path: "/etc/README.md", Document: document("Readme")}},
+			wantErr: `entry path "/etc/README.md" must be a relative path inside the repository`,
+		},
+		{
+			name:    "path outside the repository",
+			entries: []Entry{{Path: "../README.md", Document: document("Readme")}},
+			wantErr: `entry path "../README.md" must be a relative path inside the repository`,
+		},
+		{
+			name:    "unnamed document",
+			entries: []Entry{ReadMe(doyoucompute.Document{})},
+			wantErr: "document for README.md must have a name",
+		},
+		{
+			name:    "duplicate path",
+			entries: []Entry{ReadMe(document("Readme")), ReadMe(document("Other"))},
+			wantErr: "a document is already registered at README.md",
+		},
+		{
+			name:    "duplicate name",
+			entries: []Entry{ReadMe(document("Docs")), Contributing(document("Docs"))},
+			wantErr: `a document named "Docs" is already registered at README.md`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := New(tt.entries...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("New() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var got []string
+			for _, entry := range registry.Entries() {
+				got = append(got, entry.Path)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Entries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registry, err := New(ReadMe(document("Readme")), PullRequest(document("Pull Request")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	app := &fakeApp{}
+	registry.Register(app)
+
+	if strings.Join(app.registered, ",") != "Readme,Pull Request" {
+		t.Errorf("Register() registered %v", app.registered)
+	}
+}
+
+func TestRenderAndCompareAll(t *testing.T) {
+	registry, err := New(
+		ReadMe(document("Readme")),
+		Contributing(document("Contributing")),
+		PullRequest(document("Pull Request")),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	svc := &fakeService{files: map[string]string{
+		"README.md":       "Readme",
+		"CONTRIBUTING.md": "An older contributing guide",
+	}}
+
+	comparisons, err := registry.CompareAll(svc)
+	if err != nil {
+		t.Fatalf("CompareAll() error = %v", err)
+	}
+
+	want := []Comparison{
+		{Entry: registry.Entries()[0], Matches: true},
+		{Entry: registry.Entries()[1]},
+		{Entry: registry.Entries()[2], Missing: true},
+	}
+	for idx, comparison := range comparisons {
+		if comparison.Entry.Path != want[idx].Entry.Path || comparison.Matches != want[idx].Matches || comparison.Missing != want[idx].Missing {
+			t.Errorf("CompareAll()[%d] = %+v, want %+v", idx, comparison, want[idx])
+		}
+	}
+
+	if stale := Stale(comparisons); len(stale) != 2 {
+		t.Errorf("Stale() = %v, want the contributing guide and pull request template", stale)
+	}
+
+	if err := registry.RenderAll(svc); err != nil {
+		t.Fatalf("RenderAll() error = %v", err)
+	}
+
+	comparisons, err = registry.CompareAll(svc)
+	if err != nil {
+		t.Fatalf("CompareAll() error = %v", err)
+	}
+	if stale := Stale(comparisons); len(stale) != 0 {
+		t.Errorf("Stale() after RenderAll() = %v, want none", stale)
+	}
+}
+
+func TestRenderAndCompareAllErrors(t *testing.T) {
+	registry, err := New(ReadMe(document("Readme")), Contributing(document("Contributing")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	svc := &fakeService{files: map[string]string{}, failing: "CONTRIBUTING.md"}
+
+	err = registry.RenderAll(svc)
+	if err == nil || err.Error() != "could not render Contributing to CONTRIBUTING.md: disk full" {
+		t.Errorf("RenderAll() error = %v", err)
+	}
+
+	_, err = registry.CompareAll(svc)
+	if err == nil || err.Error() != "could not compare Contributing with CONTRIBUTING.md: permission denied" {
+		t.Errorf("CompareAll() error = %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+// Package repourl parses repository URLs into a structured model.
+//
+// It understands the URL shapes people actually paste into configuration:
+// HTTPS web URLs (with or without a trailing slash or ".git" suffix), SSH URLs
+// in both scp-like (git@github.com:org/repo.git) and ssh:// form, and GitLab
+// projects nested under subgroups.
+//
+// Basic usage:
+//
+//	repo, err := repourl.Parse("git@github.com:username/project.git")
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	repo.Name      // "project"
+//	repo.WebURL()  // "https://github.com/username/project"
+package repourl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Forge identifies the hosting service a repository lives on.
+type Forge int
+
+const (
+	// Unknown is used for hosts that are not recognised.
+	Unknown Forge = iota
+	// GitHub is github.com or a GitHub Enterprise host.
+	GitHub
+	// GitLab is gitlab.com or a self-managed GitLab host.
+	GitLab
+	// Bitbucket is bitbucket.org.
+	Bitbucket
+)
+
+// String returns the display name of the forge.
+func (f Forge) String() string {
+	switch f {
+	case GitHub:
+		return "GitHub"
+	case GitLab:
+		return "GitLab"
+	case Bitbucket:
+		return "Bitbucket"
+	}
+
+	return "Unknown"
+}
+
+// URL is a parsed repository location.
+type URL struct {
+	// Host is the hostname of the forge, without scheme or port
+	Host string
+	// Namespace is the owner or group path, e.g. "username" or "group/subgroup"
+	Namespace string
+	// Name is the repository name without any ".git" suffix
+	Name string
+	// Forge is the hosting service detected from Host
+	Forge Forge
+}
+
+// Parse parses a repository URL.
+// HTTPS, HTTP, ssh:// and scp-like SSH URLs are accepted.
+//
+// Example:
+//
+//	repourl.Parse("https://gitlab.com/group/subgroup/project/")
+func Parse(raw string) (URL, error) {
+	return parse(raw, 2)
+}
+
+// ParseProject parses a project URL like Parse, but also accepts URLs that only name
+// the repository, e.g. "https://example.com/project", leaving Namespace empty.
+// The issue tracker and label search URLs of such repositories cannot be derived.
+//
+// Example:
+//
+//	repourl.ParseProject("https://github.com/project")
+func ParseProject(raw string) (URL, error) {
+	return parse(raw, 1)
+}
+
+// parse parses raw, requiring at least minSegments path segments.
+func parse(raw string, minSegments int) (URL, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return URL{}, fmt.Errorf("repository url cannot be empty")
+	}
+
+	host, path, err := splitHostPath(trimmed)
+	if err != nil {
+		return URL{}, err
+	}
+
+	if host == "" {
+		return URL{}, fmt.Errorf("repository url %q has no host", raw)
+	}
+
+	forge := detectForge(host)
+
+	segments := pathSegments(path, forge)
+	if len(segments) < minSegments {
+		if minSegments == 1 {
+			return URL{}, fmt.Errorf("repository url %q must include a repository name", raw)
+		}
+
+		return URL{}, fmt.Errorf("repository url %q must include an owner and a repository name", raw)
+	}
+
+	return URL{
+		Host:      host,
+		Namespace: strings.Join(segments[:len(segments)-1], "/"),
+		Name:      segments[len(segments)-1],
+		Forge:     forge,
+	}, nil
+}
+
+// FullPath returns the namespace and name joined by a slash, or the name without a namespace.
+func (u URL) FullPath() string {
+	if u.Namespace == "" {
+		return u.Name
+	}
+
+	return u.Namespace + "/" + u.Name
+}
+
+// WebURL returns the HTTPS URL of the repository's landing page.
+func (u URL) WebURL() string {
+	return fmt.Sprintf("https://%s/%s", u.Host, u.FullPath())
+}
+
+// CloneURL returns the HTTPS clone URL of the repository.
+func (u URL) CloneURL() string {
+	return u.WebURL() + ".git"
+}
+
+// IssuesURL returns the URL of the repository's issue tracker.
+// An error is returned when the forge is not recognised, since the
+// issue tracker location cannot be derived reliably.
+func (u URL) IssuesURL() (string, error) {
+	if u.Namespace == "" {
+		return "", fmt.Errorf("cannot derive an issue tracker url for %s without an owner", u.WebURL())
+	}
+
+	switch u.Forge {
+	case GitHub, Bitbucket:
+		return u.WebURL() + "/issues", nil
+	case GitLab:
+		return u.WebURL() + "/-/issues", nil
+	}
+
+	return "", fmt.Errorf("cannot derive an issue tracker url for unknown forge host %q", u.Host)
+}
+
+// LabelSearchURL returns the URL listing the repository's open issues with the label.
+// Only GitHub and GitLab support filtering issues by label.
+func (u URL) LabelSearchURL(label string) (string, error) {
+	if label == "" {
+		return "", fmt.Errorf("label cannot be empty")
+	}
+	if u.Namespace == "" {
+		return "", fmt.Errorf("cannot derive a label search url for %s without an owner", u.WebURL())
+	}
+
+	switch u.Forge {
+	case GitHub:
+		query := fmt.Sprintf("is:issue is:open label:%q", label)
+		return u.WebURL() + "/issues?q=" + url.QueryEscape(query), nil
+	case GitLab:
+		return u.WebURL() + "/-/issues?state=opened&label_name%5B%5D=" + url.QueryEscape(label), nil
+	case Bitbucket:
+		return "", fmt.Errorf("label search is not supported for %s repositories", u.Forge)
+	}
+
+	return "", fmt.Errorf("cannot derive a label search url for unknown forge host %q", u.Host)
+}
+
+func splitHostPath(raw string) (string, string, error) {
+	if !strings.Contains(raw, "://") {
+		// scp-like syntax: [user@]host:path
+		colon := strings.Index(raw, ":")
+		slash := strings.Index(raw, "/")
+		if colon == -1 || (slash != -1 && slash < colon) {
+			return "", "", fmt.Errorf("repository url %q is missing a scheme", raw)
+		}
+
+		host := raw[:colon]
+		if at := strings.LastIndex(host, "@"); at != -1 {
+			host = host[at+1:]
+		}
+
+		return strings.ToLower(host), raw[colon+1:], nil
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository url %q: %w", raw, err)
+	}
+
+	switch parsed.Scheme {
+	case "https", "http", "ssh", "git", "git+ssh":
+	default:
+		return "", "", fmt.Errorf("repository url %q has unsupported scheme %q", raw, parsed.Scheme)
+	}
+
+	return strings.ToLower(parsed.Hostname()), parsed.Path, nil
+}
+
+func pathSegments(path string, forge Forge) []string {
+	// GitLab separates the project path from project pages with "/-/"
+	if idx := strings.Index(path, "/-/"); idx != -1 {
+		path = path[:idx]
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	// GitHub and Bitbucket only have owner/repo; anything after is a page within the repo
+	if (forge == GitHub || forge == Bitbucket) && len(segments) > 2 {
+		segments = segments[:2]
+	}
+
+	// Issue tracker urls without "/-/", such as legacy GitLab ones, end in an issues page
+	if n := len(segments); n > 2 && segments[n-1] == "issues" {
+		segments = segments[:n-1]
+	}
+
+	if n := len(segments); n > 0 {
+		segments[n-1] = strings.TrimSuffix(segments[n-1], ".git")
+		if segments[n-1] == "" {
+			segments = segments[:n-1]
+		}
+	}
+
+	return segments
+}
+
+func detectForge(host string) Forge {
+	switch {
+	case host == "github.com" || strings.HasPrefix(host, "github."):
+		return GitHub
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return GitLab
+	case host == "bitbucket.org":
+		return Bitbucket
+	}
+
+	return Unknown
+}
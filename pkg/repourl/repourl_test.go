@@ -0,0 +1,366 @@
+package repourl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		wantHost      string
+		wantNamespace string
+		wantName      string
+		wantForge     Forge
+	}{
+		{
+			name:          "https url",
+			raw:           "https://github.com/user/project",
+			wantHost:      "github.com",
+			wantNamespace: "user",
+			wantName:      "project",
+			wantForge:     GitHub,
+		},
+		{
+			name:          "trailing slash",
+			raw:           "https://github.com/user/project/",
+			wantHost:      "github.com",
+			wantNamespace: "user",
+			wantName:      "project",
+			wantForge:     GitHub,
+		},
+		{
+			name:          "git suffix",
+			raw:           "https://github.com/user/project.git",
+			wantHost:      "github.com",
+			wantNamespace: "user",
+			wantName:      "project",
+			wantForge:     GitHub,
+		},
+		{
+			name:          "scp-like ssh url",
+			raw:           "git@github.com:org/repo.git",
+			wantHost:      "github.com",
+			wantNamespace: "org",
+			wantName:      "repo",
+			wantForge:     GitHub,
+		},
+		{
+			name:          "ssh scheme with port",
+			raw:           "ssh://git@gitlab.example.com:2222/team/service.git",
+			wantHost:      "gitlab.example.com",
+			wantNamespace: "team",
+			wantName:      "service",
+			wantForge:     GitLab,
+		},
+		{
+			name:          "github page within repo",
+			raw:           "https://github.com/user/project/tree/main/pkg",
+			wantHost:      "github.com",
+			wantNamespace: "user",
+			wantName:      "project",
+			wantForge:     GitHub,
+		},
+		{
+			name:          "gitlab subgroups",
+			raw:           "https://gitlab.com/group/subgroup/project",
+			wantHost:      "gitlab.com",
+			wantNamespace: "group/subgroup",
+			wantName:      "project",
+			wantForge:     GitLab,
+		},
+		{
+			name:          "legacy gitlab issue tracker",
+			raw:           "https://gitlab.com/group/project/issues",
+			wantHost:      "gitlab.com",
+			wantNamespace: "group",
+			wantName:      "project",
+			wantForge:     GitLab,
+		},
+		{
+			name:          "issue tracker on an unknown forge",
+			raw:           "https://git.example.com/team/service/issues/",
+			wantHost:      "git.example.com",
+			wantNamespace: "team",
+			wantName:      "service",
+			wantForge:     Unknown,
+		},
+		{
+			name:          "gitlab project page",
+			raw:           "https://gitlab.com/group/subgroup/project/-/issues",
+			wantHost:      "gitlab.com",
+			wantNamespace: "group/subgroup",
+			wantName:      "project",
+			wantForge:     GitLab,
+		},
+		{
+			name:          "bitbucket",
+			raw:           "git@bitbucket.org:team/repo.git",
+			wantHost:      "bitbucket.org",
+			wantNamespace: "team",
+			wantName:      "repo",
+			wantForge:     Bitbucket,
+		},
+		{
+			name:          "unknown host",
+			raw:           "https://git.example.com/team/repo",
+			wantHost:      "git.example.com",
+			wantNamespace: "team",
+			wantName:      "repo",
+			wantForge:     Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got.Host != tt.wantHost {
+				t.Errorf("Parse() host = %v, want %v", got.Host, tt.wantHost)
+			}
+			if got.Namespace != tt.wantNamespace {
+				t.Errorf("Parse() namespace = %v, want %v", got.Namespace, tt.wantNamespace)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("Parse() name = %v, want %v", got.Name, tt.wantName)
+			}
+			if got.Forge != tt.wantForge {
+				t.Errorf("Parse() forge = %v, want %v", got.Forge, tt.wantForge)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		errMsg string
+	}{
+		{
+			name:   "empty",
+			raw:    "",
+			errMsg: "cannot be empty",
+		},
+		{
+			name:   "missing repository",
+			raw:    "https://github.com/",
+			errMsg: "must include an owner and a repository name",
+		},
+		{
+			name:   "missing owner",
+			raw:    "https://github.com/project",
+			errMsg: "must include an owner and a repository name",
+		},
+		{
+			name:   "no scheme",
+			raw:    "github.com/user/project",
+			errMsg: "missing a scheme",
+		},
+		{
+			name:   "unsupported scheme",
+			raw:    "ftp://github.com/user/project",
+			errMsg: "unsupported scheme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.raw)
+			if err == nil {
+				t.Fatalf("Parse() expected error containing %q", tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Parse() error = %v, should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParseProject(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantFullPath string
+		wantWeb      string
+		wantErr      string
+	}{
+		{
+			name:         "owner and repository",
+			raw:          "git@github.com:user/project.git",
+			wantFullPath: "user/project",
+			wantWeb:      "https://github.com/user/project",
+		},
+		{
+			name:         "repository without owner",
+			raw:          "https://github.com/project/",
+			wantFullPath: "project",
+			wantWeb:      "https://github.com/project",
+		},
+		{
+			name:    "missing repository",
+			raw:     "https://github.com/",
+			wantErr: "must include a repository name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := ParseProject(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseProject() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseProject() error = %v", err)
+			}
+
+			if got := repo.FullPath(); got != tt.wantFullPath {
+				t.Errorf("FullPath() = %v, want %v", got, tt.wantFullPath)
+			}
+			if got := repo.WebURL(); got != tt.wantWeb {
+				t.Errorf("WebURL() = %v, want %v", got, tt.wantWeb)
+			}
+		})
+	}
+}
+
+func TestURLsWithoutOwner(t *testing.T) {
+	repo, err := ParseProject("https://github.com/project")
+	if err != nil {
+		t.Fatalf("ParseProject() error = %v", err)
+	}
+
+	if _, err := repo.IssuesURL(); err == nil || !strings.Contains(err.Error(), "without an owner") {
+		t.Errorf("IssuesURL() error = %v, should contain %q", err, "without an owner")
+	}
+	if _, err := repo.LabelSearchURL("bug"); err == nil || !strings.Contains(err.Error(), "without an owner") {
+		t.Errorf("LabelSearchURL() error = %v, should contain %q", err, "without an owner")
+	}
+}
+
+func TestURLs(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantWeb      string
+		wantClone    string
+		wantIssues   string
+		wantIssueErr bool
+	}{
+		{
+			name:       "github from ssh",
+			raw:        "git@github.com:org/repo.git",
+			wantWeb:    "https://github.com/org/repo",
+			wantClone:  "https://github.com/org/repo.git",
+			wantIssues: "https://github.com/org/repo/issues",
+		},
+		{
+			name:       "gitlab subgroup",
+			raw:        "https://gitlab.com/group/sub/project.git",
+			wantWeb:    "https://gitlab.com/group/sub/project",
+			wantClone:  "https://gitlab.com/group/sub/project.git",
+			wantIssues: "https://gitlab.com/group/sub/project/-/issues",
+		},
+		{
+			name:         "unknown forge",
+			raw:          "https://git.example.com/team/repo",
+			wantWeb:      "https://git.example.com/team/repo",
+			wantClone:    "https://git.example.com/team/repo.git",
+			wantIssueErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := repo.WebURL(); got != tt.wantWeb {
+				t.Errorf("WebURL() = %v, want %v", got, tt.wantWeb)
+			}
+			if got := repo.CloneURL(); got != tt.wantClone {
+				t.Errorf("CloneURL() = %v, want %v", got, tt.wantClone)
+			}
+
+			issues, err := repo.IssuesURL()
+			if (err != nil) != tt.wantIssueErr {
+				t.Fatalf("IssuesURL() error = %v, wantErr %v", err, tt.wantIssueErr)
+			}
+			if issues != tt.wantIssues {
+				t.Errorf("IssuesURL() = %v, want %v", issues, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestLabelSearchURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		label   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "github",
+			raw:   "https://github.com/org/repo",
+			label: "good first issue",
+			want:  "https://github.com/org/repo/issues?q=is%3Aissue+is%3Aopen+label%3A%22good+first+issue%22",
+		},
+		{
+			name:  "gitlab subgroup",
+			raw:   "git@gitlab.com:group/sub/project.git",
+			label: "help wanted",
+			want:  "https://gitlab.com/group/sub/project/-/issues?state=opened&label_name%5B%5D=help+wanted",
+		},
+		{
+			name:    "bitbucket",
+			raw:     "https://bitbucket.org/team/repo",
+			label:   "bug",
+			wantErr: "not supported for Bitbucket",
+		},
+		{
+			name:    "unknown forge",
+			raw:     "https://git.example.com/team/repo",
+			label:   "bug",
+			wantErr: "unknown forge host",
+		},
+		{
+			name:    "empty label",
+			raw:     "https://github.com/org/repo",
+			wantErr: "label cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := repo.LabelSearchURL(tt.label)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LabelSearchURL() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LabelSearchURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LabelSearchURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
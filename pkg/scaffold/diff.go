@@ -0,0 +1,160 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DIFF_CONTEXT is the number of unchanged lines shown around each change in a diff.
+const DIFF_CONTEXT = 3
+
+// diffOp is a line of a diff: ' ' for unchanged, '-' for removed and '+' for added.
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// Diff returns a unified diff from current to proposed, or an empty string when they match.
+// Files are compared line by line; oldName and newName label the two sides.
+func Diff(oldName, newName, current, proposed string) string {
+	if current == proposed {
+		return ""
+	}
+
+	ops := diffLines(splitLines(current), splitLines(proposed))
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", oldName, newName)
+
+	for _, hunk := range hunks(ops) {
+		oldStart, oldCount, newStart, newCount := hunk.bounds(ops)
+		fmt.Fprintf(&builder, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+
+		for _, op := range ops[hunk.start:hunk.end] {
+			builder.WriteByte(op.kind)
+			builder.WriteString(op.text)
+			builder.WriteByte('\n')
+		}
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines returns the edit script turning a into b, from their longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', text: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{kind: '-', text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{kind: '+', text: b[j]})
+	}
+
+	return ops
+}
+
+// hunk is a range of ops containing changes and their surrounding context.
+type hunk struct {
+	start int
+	end   int
+}
+
+// bounds returns the 1-based starting line and line count of the hunk on each side.
+func (h hunk) bounds(ops []diffOp) (int, int, int, int) {
+	oldLine, newLine := 1, 1
+	for _, op := range ops[:h.start] {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, op := range ops[h.start:h.end] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+
+	return oldLine, oldCount, newLine, newCount
+}
+
+// hunks groups changed ops with DIFF_CONTEXT lines of context, merging hunks that overlap.
+func hunks(ops []diffOp) []hunk {
+	var found []hunk
+
+	for idx, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+
+		start := max(idx-DIFF_CONTEXT, 0)
+		end := min(idx+DIFF_CONTEXT+1, len(ops))
+
+		if len(found) > 0 && start <= found[len(found)-1].end {
+			found[len(found)-1].end = end
+			continue
+		}
+
+		found = append(found, hunk{start: start, end: end})
+	}
+
+	return found
+}
+
+// hunkRange formats a hunk's start and count, using the line before an empty range as diff does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,51 @@
+package scaffold
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		proposed string
+		want     string
+	}{
+		{
+			name:     "identical",
+			current:  "a\nb\n",
+			proposed: "a\nb\n",
+			want:     "",
+		},
+		{
+			name:     "new file",
+			current:  "",
+			proposed: "a\nb\n",
+			want:     "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "changed line keeps context",
+			current:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			proposed: "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want:     "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:     "distant changes make separate hunks",
+			current:  "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			proposed: "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want:     "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+		{
+			name:     "removed lines",
+			current:  "keep\ndrop\n",
+			proposed: "keep\n",
+			want:     "--- old\n+++ new\n@@ -1,2 +1 @@\n keep\n-drop\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff("old", "new", tt.current, tt.proposed); got != tt.want {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
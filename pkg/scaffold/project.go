@@ -0,0 +1,135 @@
+package scaffold
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+// Project is the metadata the community files are generated from.
+type Project struct {
+	// Name is the title of the README
+	Name string
+	// Description is the introduction paragraph of the README; optional
+	Description string
+	// Info is the detected project metadata
+	Info metadata.ProjectInfo
+}
+
+// DetectProject reads project metadata from the repository rooted at fsys.
+// The name defaults to the repository name, or the last element of the module path.
+//
+// Example:
+//
+//	project, err := scaffold.DetectProject(os.DirFS("."))
+func DetectProject(fsys fs.FS) (Project, error) {
+	info, err := metadata.Detect(fsys)
+	if err != nil {
+		return Project{}, err
+	}
+
+	project := Project{Info: info}
+
+	switch {
+	case info.HasRepository():
+		project.Name = info.Repository.Name
+	case info.ModulePath != "":
+		project.Name = path.Base(info.ModulePath)
+	}
+
+	return project, nil
+}
+
+// SetRepository replaces the detected repository with the one at url.
+func (p *Project) SetRepository(url string) error {
+	repository, err := repourl.Parse(url)
+	if err != nil {
+		return fmt.Errorf("invalid repository url: %w", err)
+	}
+
+	p.Info.RemoteUrl = url
+	p.Info.Repository = repository
+
+	return nil
+}
+
+// Valid returns an error if the project is missing metadata every template needs.
+func (p Project) Valid() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("project name cannot be empty")
+	}
+
+	return nil
+}
+
+// Ask prompts on out for the metadata that could not be detected and reads the answers from in.
+// Only empty fields are asked for. The description and repository may be left blank,
+// and are left empty when in runs out of answers.
+//
+// Example:
+//
+//	project, err = scaffold.Ask(os.Stdin, os.Stdout, project)
+func Ask(in io.Reader, out io.Writer, project Project) (Project, error) {
+	scanner := bufio.NewScanner(in)
+
+	ask := func(question string) (string, error) {
+		fmt.Fprintf(out, "%s: ", question)
+
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("could not read answer: %w", err)
+			}
+
+			return "", fmt.Errorf("no answer to %q: %w", question, io.ErrUnexpectedEOF)
+		}
+
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+
+	for project.Name == "" {
+		name, err := ask("Project name")
+		if err != nil {
+			return Project{}, err
+		}
+
+		project.Name = name
+	}
+
+	if !project.Info.HasRepository() {
+		for {
+			url, err := ask("Repository url (leave blank to skip the contributing guide)")
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return Project{}, err
+			}
+
+			if url == "" {
+				break
+			}
+
+			if err := project.SetRepository(url); err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+
+			break
+		}
+	}
+
+	if project.Description == "" {
+		description, err := ask("Short description (optional)")
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return Project{}, err
+		}
+
+		project.Description = description
+	}
+
+	return project, nil
+}
@@ -0,0 +1,146 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+func TestDetectProject(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "repository name",
+			fsys: fstest.MapFS{
+				"go.mod":      {Data: []byte("module example.com/acme/widget\n")},
+				".git/config": {Data: []byte("[remote \"origin\"]\n\turl = git@github.com:acme/gadget.git\n")},
+			},
+			want: "gadget",
+		},
+		{
+			name: "module path",
+			fsys: fstest.MapFS{
+				"go.mod": {Data: []byte("module example.com/acme/widget\n")},
+			},
+			want: "widget",
+		},
+		{
+			name: "nothing detected",
+			fsys: fstest.MapFS{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := DetectProject(tt.fsys)
+			if err != nil {
+				t.Fatalf("DetectProject() error = %v", err)
+			}
+
+			if project.Name != tt.want {
+				t.Errorf("DetectProject() name = %q, want %q", project.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsk(t *testing.T) {
+	tests := []struct {
+		name           string
+		project        Project
+		answers        string
+		wantName       string
+		wantRepository string
+		wantDesc       string
+		wantPrompts    int
+		wantErr        string
+	}{
+		{
+			name:           "asks for everything missing",
+			answers:        "widget\nnot a url\nhttps://github.com/acme/widget\nMakes widgets\n",
+			wantName:       "widget",
+			wantRepository: "acme/widget",
+			wantDesc:       "Makes widgets",
+			wantPrompts:    4,
+		},
+		{
+			name:        "blank name is asked again",
+			answers:     "\nwidget\n\n\n",
+			wantName:    "widget",
+			wantPrompts: 4,
+		},
+		{
+			name: "detected metadata is not asked for",
+			project: Project{
+				Name:        "widget",
+				Description: "Makes widgets",
+				Info:        detectedInfo(t, "https://gitlab.com/acme/widget"),
+			},
+			wantName:       "widget",
+			wantRepository: "acme/widget",
+			wantDesc:       "Makes widgets",
+		},
+		{
+			name:        "optional answers can run out",
+			answers:     "widget\n",
+			wantName:    "widget",
+			wantPrompts: 3,
+		},
+		{
+			name:    "name is required",
+			answers: "",
+			wantErr: `no answer to "Project name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			got, err := Ask(strings.NewReader(tt.answers), &out, tt.project)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Ask() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+
+			repository := ""
+			if got.Info.HasRepository() {
+				repository = got.Info.Repository.FullPath()
+			}
+
+			if got.Name != tt.wantName || got.Description != tt.wantDesc || repository != tt.wantRepository {
+				t.Errorf("Ask() = %q, %q, %q", got.Name, repository, got.Description)
+			}
+
+			if prompts := strings.Count(out.String(), ": "); prompts < tt.wantPrompts || (tt.wantPrompts == 0 && prompts != 0) {
+				t.Errorf("Ask() prompted %d times, want %d:\n%s", prompts, tt.wantPrompts, out.String())
+			}
+		})
+	}
+}
+
+func detectedInfo(t *testing.T, url string) (info metadata.ProjectInfo) {
+	t.Helper()
+
+	repository, err := repourl.Parse(url)
+	if err != nil {
+		t.Fatalf("repourl.Parse() error = %v", err)
+	}
+
+	info.RemoteUrl = url
+	info.Repository = repository
+
+	return info
+}
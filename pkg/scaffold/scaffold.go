@@ -0,0 +1,429 @@
+// Package scaffold generates a project's full set of community files in one go.
+//
+// It builds the README, contributing guide, pull request template and bug report
+// template from detected project metadata, compares them with the files already
+// in the repository, and writes the ones that are new. Existing files are only
+// overwritten when forced, after their diff has been shown.
+//
+// Basic usage:
+//
+//	project, err := scaffold.DetectProject(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	err = scaffold.Init(".", project, os.Stdout)
+//
+// Previewing the changes to two of the files without writing them:
+//
+//	err = scaffold.Init(".", project, os.Stdout,
+//		scaffold.WithOnly(scaffold.README, scaffold.CONTRIBUTING),
+//		scaffold.WithDryRun(),
+//	)
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/makefile"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/pullrequest"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/registry"
+)
+
+// Names of the templates Init generates, as accepted by WithOnly.
+const (
+	README       = "readme"
+	CONTRIBUTING = "contributing"
+	PULL_REQUEST = "pullrequest"
+	BUG_REPORT   = "bugreport"
+)
+
+// TEMPLATES lists every template Init generates, in the order they are written.
+var TEMPLATES = []string{README, CONTRIBUTING, PULL_REQUEST, BUG_REPORT}
+
+// Documents builds the named templates for the project, reading the Makefile,
+// workflows and license from the repository in fsys.
+// The contributing guide requires the project to have a repository.
+func Documents(project Project, fsys fs.FS, names ...string) (*registry.Registry, error) {
+	if err := project.Valid(); err != nil {
+		return nil, err
+	}
+
+	docs, err := registry.New()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		var entry registry.Entry
+
+		switch name {
+		case README:
+			doc, err := readmeFor(project, fsys)
+			if err != nil {
+				return nil, err
+			}
+			entry = registry.ReadMe(doc)
+		case CONTRIBUTING:
+			doc, err := contributingFor(project, fsys)
+			if err != nil {
+				return nil, err
+			}
+			entry = registry.Contributing(doc)
+		case PULL_REQUEST:
+			doc, err := pullrequest.New(pullrequest.WithProjectInfo(project.Info))
+			if err != nil {
+				return nil, err
+			}
+			entry = registry.PullRequest(doc)
+		case BUG_REPORT:
+			doc, err := bugreport.New(bugreport.WithProjectInfo(project.Info), bugreport.WithLabels(labels.Bug()))
+			if err != nil {
+				return nil, err
+			}
+			entry = registry.BugReport(doc)
+		default:
+			return nil, unknownTemplate(name)
+		}
+
+		if err := docs.Add(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+func unknownTemplate(name string) error {
+	return fmt.Errorf("unknown template %q, expected one of %s", name, strings.Join(TEMPLATES, ", "))
+}
+
+// readmeFor builds a README with placeholder features for the project to fill in.
+func readmeFor(project Project, fsys fs.FS) (doyoucompute.Document, error) {
+	intro := doyoucompute.NewParagraph()
+	if project.Description != "" {
+		intro.Text(project.Description)
+	} else {
+		intro.Text(fmt.Sprintf("%s is a work in progress, describe what it does here.", project.Name))
+	}
+
+	features, _ := doyoucompute.SectionFactory("Features", func(s *doyoucompute.Section) error {
+		s.WriteComment("List the main features of the project.")
+
+		return nil
+	})
+
+	quickstart, _ := doyoucompute.SectionFactory("Quickstart", func(s *doyoucompute.Section) error {
+		if install := project.Info.InstallCommand(); install != "" {
+			installation := s.CreateSection("Installation")
+			installation.WriteCodeBlock("bash", []string{install}, doyoucompute.Static)
+		}
+
+		s.WriteComment("Show how to get started with the project.")
+
+		return nil
+	})
+
+	return readme.New(
+		readme.ReadmeProps{
+			Name:       project.Name,
+			Intro:      *intro,
+			Features:   features,
+			QuickStart: quickstart,
+		},
+		nil,
+		readme.WithProjectInfo(project.Info),
+		readme.WithProjectBadges(project.Info, fsys),
+	)
+}
+
+// contributingFor builds a contributing guide listing the documented Makefile targets, if there are any.
+func contributingFor(project Project, fsys fs.FS) (doyoucompute.Document, error) {
+	targets, err := makefile.ParseFile(fsys, "Makefile")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return doyoucompute.Document{}, err
+	}
+
+	if documented := makefile.Documented(targets); len(documented) > 0 {
+		return contributing.NewFromProject(project.Info, contributing.WithMakefileTasks(documented))
+	}
+
+	return contributing.NewFromProject(project.Info)
+}
+
+// Action is what Init does with a generated file.
+type Action int
+
+const (
+	// Create writes a file that does not exist yet
+	Create Action = iota
+	// Update overwrites an existing file whose content differs
+	Update
+	// Unchanged leaves an existing file that is already up to date
+	Unchanged
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case Create:
+		return "create"
+	case Update:
+		return "update"
+	case Unchanged:
+		return "unchanged"
+	}
+
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// Change is a generated file and how it differs from the file in the repository.
+type Change struct {
+	// Path is the file's path relative to the repository root
+	Path string
+	// Action is what writing the file would do
+	Action Action
+	// Current is the content of the existing file, empty when it does not exist
+	Current string
+	// Proposed is the rendered content of the document
+	Proposed string
+}
+
+// Diff returns a unified diff from the existing file to the generated one.
+func (c Change) Diff() string {
+	oldName := "a/" + c.Path
+	if c.Action == Create {
+		oldName = "/dev/null"
+	}
+
+	return Diff(oldName, "b/"+c.Path, c.Current, c.Proposed)
+}
+
+// Plan renders every document and compares it with the file at its path in fsys.
+func Plan(fsys fs.FS, docs *registry.Registry) ([]Change, error) {
+	entries := docs.Entries()
+	changes := make([]Change, 0, len(entries))
+
+	for _, entry := range entries {
+		proposed, err := doyoucompute.NewMarkdownRenderer().Render(&entry.Document)
+		if err != nil {
+			return nil, fmt.Errorf("could not render %s: %w", entry.Path, err)
+		}
+
+		change := Change{Path: entry.Path, Action: Create, Proposed: proposed}
+
+		current, err := fs.ReadFile(fsys, entry.Path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("could not read %s: %w", entry.Path, err)
+		case string(current) == proposed:
+			change.Action = Unchanged
+			change.Current = string(current)
+		default:
+			change.Action = Update
+			change.Current = string(current)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// Write writes the change's file under dir, creating parent directories as needed.
+func Write(dir string, change Change) error {
+	name := filepath.Join(dir, filepath.FromSlash(change.Path))
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("could not create directory for %s: %w", change.Path, err)
+	}
+
+	if err := os.WriteFile(name, []byte(change.Proposed), 0o644); err != nil {
+		return fmt.Errorf("could not write %s: %w", change.Path, err)
+	}
+
+	return nil
+}
+
+// InitProps holds the settings applied by the options passed to Init.
+type InitProps struct {
+	only   []string
+	dryRun bool
+	force  bool
+}
+
+// WithOnly limits Init to the named templates.
+//
+// Example:
+//
+//	scaffold.WithOnly(scaffold.PULL_REQUEST, scaffold.BUG_REPORT)
+func WithOnly(names ...string) doyoucompute.OptionBuilder[InitProps] {
+	return func(p *InitProps) (doyoucompute.Finalizer[InitProps], error) {
+		for _, name := range names {
+			if !isTemplate(name) {
+				return nil, unknownTemplate(name)
+			}
+		}
+
+		p.only = names
+
+		return nil, nil
+	}
+}
+
+// WithDryRun reports what Init would write, including diffs, without writing anything.
+//
+// Example:
+//
+//	scaffold.WithDryRun()
+func WithDryRun() doyoucompute.OptionBuilder[InitProps] {
+	return func(p *InitProps) (doyoucompute.Finalizer[InitProps], error) {
+		p.dryRun = true
+
+		return nil, nil
+	}
+}
+
+// WithForce overwrites existing files that differ from the generated ones.
+// Without it, those files are left untouched and only their diff is shown.
+//
+// Example:
+//
+//	scaffold.WithForce()
+func WithForce() doyoucompute.OptionBuilder[InitProps] {
+	return func(p *InitProps) (doyoucompute.Finalizer[InitProps], error) {
+		p.force = true
+
+		return nil, nil
+	}
+}
+
+func isTemplate(name string) bool {
+	for _, template := range TEMPLATES {
+		if name == template {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Init generates the project's community files into the repository at dir and reports each
+// file on out. New files are written; existing files that differ are shown as a diff and
+// overwritten only with WithForce. When the project has no repository and no templates were
+// selected with WithOnly, the contributing guide is skipped.
+//
+// Example:
+//
+//	err := scaffold.Init(".", project, os.Stdout, scaffold.WithForce())
+func Init(dir string, project Project, out io.Writer, opts ...doyoucompute.OptionBuilder[InitProps]) error {
+	props := InitProps{}
+
+	if err := doyoucompute.ApplyOptions(&props, opts...); err != nil {
+		return err
+	}
+
+	names := props.only
+	if len(names) == 0 {
+		for _, name := range TEMPLATES {
+			if name == CONTRIBUTING && !project.Info.HasRepository() {
+				fmt.Fprintf(out, "skip      %s (no repository url)\n", contributing.DEFAULT_PATH)
+				continue
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	docs, err := Documents(project, os.DirFS(dir), names...)
+	if err != nil {
+		return err
+	}
+
+	return apply(dir, docs, out, props)
+}
+
+// Apply writes the registered documents into the repository at dir and reports each file on out,
+// the same way Init does. WithDryRun and WithForce apply; WithOnly has no effect, since the
+// documents are already chosen.
+//
+// Example:
+//
+//	cfg, err := config.Load(os.DirFS("."), config.DEFAULT_PATH)
+//	if err != nil {
+//		// handle error
+//	}
+//	docs, err := templates.Documents(cfg, templates.Builtin()...)
+//	if err != nil {
+//		// handle error
+//	}
+//	err = scaffold.Apply(".", docs, os.Stdout, scaffold.WithDryRun())
+func Apply(dir string, docs *registry.Registry, out io.Writer, opts ...doyoucompute.OptionBuilder[InitProps]) error {
+	props := InitProps{}
+
+	if err := doyoucompute.ApplyOptions(&props, opts...); err != nil {
+		return err
+	}
+
+	return apply(dir, docs, out, props)
+}
+
+func apply(dir string, docs *registry.Registry, out io.Writer, props InitProps) error {
+	changes, err := Plan(os.DirFS(dir), docs)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		write := change.Action == Create || (change.Action == Update && props.force)
+
+		status := change.Action.String()
+		if change.Action == Update && !props.force {
+			status = "differs"
+		}
+
+		fmt.Fprintf(out, "%-9s %s\n", status, change.Path)
+
+		if change.Action == Update {
+			fmt.Fprint(out, change.Diff())
+		}
+
+		if !write || props.dryRun {
+			continue
+		}
+
+		if err := Write(dir, change); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case props.dryRun:
+		fmt.Fprintln(out, "dry run, no files were written")
+	case !props.force && hasAction(changes, Update):
+		fmt.Fprintln(out, "existing files that differ were not overwritten, rerun with force to replace them")
+	}
+
+	return nil
+}
+
+func hasAction(changes []Change, action Action) bool {
+	for _, change := range changes {
+		if change.Action == action {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,251 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// newRepository returns a directory containing a go.mod and the given files.
+func newRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files["go.mod"] = "module github.com/acme/widget\n\ngo 1.22\n"
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func detect(t *testing.T, dir string) Project {
+	t.Helper()
+
+	project, err := DetectProject(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("DetectProject() error = %v", err)
+	}
+
+	return project
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+func TestDocuments(t *testing.T) {
+	dir := newRepository(t, map[string]string{})
+	project := detect(t, dir)
+
+	docs, err := Documents(project, os.DirFS(dir), TEMPLATES...)
+	if err != nil {
+		t.Fatalf("Documents() error = %v", err)
+	}
+
+	var paths []string
+	for _, entry := range docs.Entries() {
+		paths = append(paths, entry.Path)
+	}
+
+	want := "README.md,CONTRIBUTING.md,.github/PULL_REQUEST_TEMPLATE.md,.github/ISSUE_TEMPLATE/bug_report.md"
+	if strings.Join(paths, ",") != want {
+		t.Errorf("Documents() paths = %v, want %s", paths, want)
+	}
+
+	if _, err := Documents(project, os.DirFS(dir), "changelog"); err == nil || !strings.Contains(err.Error(), `unknown template "changelog"`) {
+		t.Errorf("Documents() error = %v, want unknown template", err)
+	}
+
+	if _, err := Documents(Project{}, os.DirFS(dir), README); err == nil {
+		t.Errorf("Documents() without a project name should fail")
+	}
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		description string
+		only        []string
+		dryRun      bool
+		force       bool
+		wantOut     []string
+		wantFiles   map[string]string
+		wantMissing []string
+	}{
+		{
+			name:        "writes every template",
+			files:       map[string]string{},
+			description: "Makes widgets",
+			wantOut: []string{
+				"create    README.md",
+				"create    CONTRIBUTING.md",
+				"create    .github/PULL_REQUEST_TEMPLATE.md",
+				"create    .github/ISSUE_TEMPLATE/bug_report.md",
+			},
+			wantFiles: map[string]string{
+				"README.md":                            "Makes widgets",
+				"CONTRIBUTING.md":                      "https://github.com/acme/widget",
+				".github/ISSUE_TEMPLATE/bug_report.md": "labels: bug",
+			},
+		},
+		{
+			name:        "dry run writes nothing",
+			files:       map[string]string{},
+			dryRun:      true,
+			wantOut:     []string{"create    README.md", "dry run, no files were written"},
+			wantMissing: []string{"README.md", "CONTRIBUTING.md"},
+		},
+		{
+			name:        "only the selected templates",
+			files:       map[string]string{},
+			only:        []string{PULL_REQUEST},
+			wantOut:     []string{"create    .github/PULL_REQUEST_TEMPLATE.md"},
+			wantFiles:   map[string]string{".github/PULL_REQUEST_TEMPLATE.md": "## Description"},
+			wantMissing: []string{"README.md"},
+		},
+		{
+			name:      "existing files are diffed but kept",
+			files:     map[string]string{"README.md": "# widget\n\nHand written.\n"},
+			only:      []string{README},
+			wantOut:   []string{"differs   README.md", "--- a/README.md", "-Hand written.", "rerun with force"},
+			wantFiles: map[string]string{"README.md": "Hand written."},
+		},
+		{
+			name:      "force overwrites existing files",
+			files:     map[string]string{"README.md": "# widget\n\nHand written.\n"},
+			only:      []string{README},
+			force:     true,
+			wantOut:   []string{"update    README.md", "-Hand written."},
+			wantFiles: map[string]string{"README.md": "## Features"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newRepository(t, tt.files)
+			project := detect(t, dir)
+			project.Description = tt.description
+
+			// Stand-in for an origin remote, which the contributing guide needs
+			if err := project.SetRepository("https://github.com/acme/widget"); err != nil {
+				t.Fatal(err)
+			}
+
+			var opts []doyoucompute.OptionBuilder[InitProps]
+			if len(tt.only) > 0 {
+				opts = append(opts, WithOnly(tt.only...))
+			}
+			if tt.dryRun {
+				opts = append(opts, WithDryRun())
+			}
+			if tt.force {
+				opts = append(opts, WithForce())
+			}
+
+			var out strings.Builder
+			if err := Init(dir, project, &out, opts...); err != nil {
+				t.Fatalf("Init() error = %v", err)
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Init() output should contain %q:\n%s", want, out.String())
+				}
+			}
+
+			for name, want := range tt.wantFiles {
+				if got := read(t, dir, name); !strings.Contains(got, want) {
+					t.Errorf("%s should contain %q:\n%s", name, want, got)
+				}
+			}
+
+			for _, name := range tt.wantMissing {
+				if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+					t.Errorf("%s should not have been written", name)
+				}
+			}
+		})
+	}
+}
+
+func TestInitWithoutRepository(t *testing.T) {
+	dir := newRepository(t, map[string]string{})
+	project := Project{Name: "widget"}
+
+	var out strings.Builder
+	if err := Init(dir, project, &out); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if !strings.Contains(out.String(), "skip      CONTRIBUTING.md (no repository url)") {
+		t.Errorf("Init() output should report the skipped contributing guide:\n%s", out.String())
+	}
+
+	if read(t, dir, "CONTRIBUTING.md") != "" || read(t, dir, "README.md") == "" {
+		t.Errorf("Init() should write the README but not the contributing guide")
+	}
+
+	err := Init(dir, project, &out, WithOnly(CONTRIBUTING))
+	if err == nil || !strings.Contains(err.Error(), "no repository") {
+		t.Errorf("Init() error = %v, want missing repository", err)
+	}
+}
+
+func TestWithOnlyUnknownTemplate(t *testing.T) {
+	err := Init(t.TempDir(), Project{Name: "widget"}, &strings.Builder{}, WithOnly("changelog"))
+	if err == nil || err.Error() != `unknown template "changelog", expected one of readme, contributing, pullrequest, bugreport` {
+		t.Errorf("Init() error = %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	dir := newRepository(t, map[string]string{})
+	project := detect(t, dir)
+
+	docs, err := Documents(project, os.DirFS(dir), BUG_REPORT)
+	if err != nil {
+		t.Fatalf("Documents() error = %v", err)
+	}
+
+	var out strings.Builder
+	if err := Apply(dir, docs, &out, WithDryRun()); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if read(t, dir, ".github/ISSUE_TEMPLATE/bug_report.md") != "" {
+		t.Errorf("Apply() with a dry run should not write files")
+	}
+
+	if err := Apply(dir, docs, &out); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if read(t, dir, ".github/ISSUE_TEMPLATE/bug_report.md") == "" {
+		t.Errorf("Apply() should write the bug report template")
+	}
+
+	out.Reset()
+	if err := Apply(dir, docs, &out); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if out.String() != "unchanged .github/ISSUE_TEMPLATE/bug_report.md\n" {
+		t.Errorf("Apply() output = %q", out.String())
+	}
+}
@@ -0,0 +1,213 @@
+// Package sections adds, moves and removes the sections of a generated document.
+//
+// Every template accepts edits through its WithSections option, so a default
+// section can be dropped or a project-specific one added without rebuilding the
+// document by hand. Sections are found by heading at any depth, and an edit that
+// names a section the document does not have fails with ErrUnknownSection.
+//
+// Basic usage:
+//
+//	screenshots, err := doyoucompute.SectionFactory("Screenshots", func(s *doyoucompute.Section) error {
+//		s.WriteComment("Drag screenshots of the problem here.")
+//		return nil
+//	})
+//	if err != nil {
+//		// handle error
+//	}
+//	doc, err := bugreport.New(bugreport.WithSections(
+//		sections.InsertAfter("Actual behavior", screenshots),
+//		sections.Remove("Code Samples"),
+//	))
+//
+// Editing a document that was already built:
+//
+//	err := sections.Apply(&doc, sections.Append(securitySection))
+package sections
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// ErrUnknownSection is returned when an edit names a section the document does not have.
+var ErrUnknownSection = errors.New("unknown section")
+
+// Edit changes the sections of a document.
+type Edit func(d *doyoucompute.Document) error
+
+// Apply applies the edits to the document in order, stopping at the first failure.
+func Apply(d *doyoucompute.Document, edits ...Edit) error {
+	for _, edit := range edits {
+		if err := edit(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Append adds a section after the document's last section.
+//
+// Example:
+//
+//	sections.Append(securitySection)
+func Append(section doyoucompute.Section) Edit {
+	return func(d *doyoucompute.Document) error {
+		if err := section.Valid(); err != nil {
+			return err
+		}
+
+		d.AddSection(section)
+
+		return nil
+	}
+}
+
+// InsertBefore adds a section just before the section with the heading name, at the same depth.
+//
+// Example:
+//
+//	sections.InsertBefore("How I tested", securitySection)
+func InsertBefore(name string, section doyoucompute.Section) Edit {
+	return insert(name, section, 0)
+}
+
+// InsertAfter adds a section just after the section with the heading name, at the same depth.
+//
+// Example:
+//
+//	sections.InsertAfter("Actual behavior", screenshotsSection)
+func InsertAfter(name string, section doyoucompute.Section) Edit {
+	return insert(name, section, 1)
+}
+
+func insert(name string, section doyoucompute.Section, offset int) Edit {
+	return func(d *doyoucompute.Document) error {
+		if err := section.Valid(); err != nil {
+			return err
+		}
+
+		return edit(d, name, func(nodes []doyoucompute.Node, idx int) []doyoucompute.Node {
+			idx += offset
+
+			inserted := append([]doyoucompute.Node{}, nodes[:idx]...)
+			inserted = append(inserted, section)
+
+			return append(inserted, nodes[idx:]...)
+		})
+	}
+}
+
+// Remove drops the section with the heading name, along with its subsections.
+//
+// Example:
+//
+//	sections.Remove("Code Samples")
+func Remove(name string) Edit {
+	return func(d *doyoucompute.Document) error {
+		return edit(d, name, func(nodes []doyoucompute.Node, idx int) []doyoucompute.Node {
+			return append(append([]doyoucompute.Node{}, nodes[:idx]...), nodes[idx+1:]...)
+		})
+	}
+}
+
+// RemoveAll drops every section with the heading name, at any depth, along with their subsections.
+// Like Remove, it fails with ErrUnknownSection when the document has no such section.
+//
+// Example:
+//
+//	sections.RemoveAll("Examples")
+func RemoveAll(name string) Edit {
+	return func(d *doyoucompute.Document) error {
+		if err := Remove(name)(d); err != nil {
+			return err
+		}
+
+		for slices.Contains(Names(*d), name) {
+			if err := Remove(name)(d); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Names returns the headings of every section in the document, depth first.
+func Names(d doyoucompute.Document) []string {
+	return names(d.Content)
+}
+
+func names(nodes []doyoucompute.Node) []string {
+	var found []string
+
+	for _, node := range nodes {
+		if section, ok := asSection(node); ok {
+			found = append(found, section.Name)
+			found = append(found, names(section.Content)...)
+		}
+	}
+
+	return found
+}
+
+// edit finds the first section with the heading name, depth first, and replaces the nodes
+// containing it with the result of fn, which is given the section's index.
+func edit(d *doyoucompute.Document, name string, fn func(nodes []doyoucompute.Node, idx int) []doyoucompute.Node) error {
+	content, found := editNodes(d.Content, name, fn)
+	if !found {
+		return fmt.Errorf("%w %q in %s, expected one of %s", ErrUnknownSection, name, d.Name, strings.Join(Names(*d), ", "))
+	}
+
+	d.Content = content
+
+	return nil
+}
+
+func editNodes(nodes []doyoucompute.Node, name string, fn func(nodes []doyoucompute.Node, idx int) []doyoucompute.Node) ([]doyoucompute.Node, bool) {
+	for idx, node := range nodes {
+		section, ok := asSection(node)
+		if !ok {
+			continue
+		}
+
+		if section.Name == name {
+			return fn(nodes, idx), true
+		}
+
+		content, found := editNodes(section.Content, name, fn)
+		if !found {
+			continue
+		}
+
+		// The nodes may belong to the props the document was built from, so the edited section
+		// is copied into a copy of them, whether it was added by value or by pointer
+		section.Content = content
+
+		edited := slices.Clone(nodes)
+		if _, ok := node.(*doyoucompute.Section); ok {
+			edited[idx] = &section
+		} else {
+			edited[idx] = section
+		}
+
+		return edited, true
+	}
+
+	return nodes, false
+}
+
+func asSection(node doyoucompute.Node) (doyoucompute.Section, bool) {
+	switch section := node.(type) {
+	case doyoucompute.Section:
+		return section, true
+	case *doyoucompute.Section:
+		return *section, true
+	}
+
+	return doyoucompute.Section{}, false
+}
@@ -0,0 +1,157 @@
+package sections
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// document returns a document with sections A and B, where B has a subsection B1 added by pointer,
+// and C, whose subsection C1 is added by value.
+func document() doyoucompute.Document {
+	doc, _ := doyoucompute.DocumentFactory("Doc", func(d *doyoucompute.Document) error {
+		d.WriteIntro().Text("Intro")
+		d.CreateSection("A")
+		d.CreateSection("B").CreateSection("B1")
+
+		c := doyoucompute.NewSection("C")
+		c.AddSection(doyoucompute.NewSection("C1"))
+		d.AddSection(c)
+
+		return nil
+	})
+
+	return doc
+}
+
+func section(name string) doyoucompute.Section {
+	return doyoucompute.NewSection(name)
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		edits   []Edit
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no edits",
+			want: []string{"A", "B", "B1", "C", "C1"},
+		},
+		{
+			name:  "append",
+			edits: []Edit{Append(section("D"))},
+			want:  []string{"A", "B", "B1", "C", "C1", "D"},
+		},
+		{
+			name:  "insert before a top-level section",
+			edits: []Edit{InsertBefore("A", section("Z"))},
+			want:  []string{"Z", "A", "B", "B1", "C", "C1"},
+		},
+		{
+			name:  "insert after a nested section",
+			edits: []Edit{InsertAfter("B1", section("B2")), InsertAfter("C1", section("C2"))},
+			want:  []string{"A", "B", "B1", "B2", "C", "C1", "C2"},
+		},
+		{
+			name:  "remove a section and its subsections",
+			edits: []Edit{Remove("B")},
+			want:  []string{"A", "C", "C1"},
+		},
+		{
+			name:  "remove nested sections",
+			edits: []Edit{Remove("B1"), Remove("C1")},
+			want:  []string{"A", "B", "C"},
+		},
+		{
+			name:  "remove every section with a heading",
+			edits: []Edit{InsertAfter("B1", section("C1")), RemoveAll("C1")},
+			want:  []string{"A", "B", "B1", "C"},
+		},
+		{
+			name:  "remove only the first section with a heading",
+			edits: []Edit{InsertAfter("B1", section("C1")), Remove("C1")},
+			want:  []string{"A", "B", "B1", "C", "C1"},
+		},
+		{
+			name:    "remove all of an unknown section",
+			edits:   []Edit{RemoveAll("Screenshots")},
+			wantErr: `unknown section "Screenshots" in Doc, expected one of A, B, B1, C, C1`,
+		},
+		{
+			name:  "edits apply in order",
+			edits: []Edit{Append(section("D")), InsertBefore("D", section("Before D")), Remove("A")},
+			want:  []string{"B", "B1", "C", "C1", "Before D", "D"},
+		},
+		{
+			name:    "unknown section",
+			edits:   []Edit{Remove("A"), Remove("Screenshots")},
+			wantErr: `unknown section "Screenshots" in Doc, expected one of B, B1, C, C1`,
+		},
+		{
+			name:    "unnamed section",
+			edits:   []Edit{InsertAfter("A", doyoucompute.Section{})},
+			wantErr: "section name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := document()
+
+			err := Apply(&doc, tt.edits...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Apply() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			if got := Names(doc); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Names() = %v, want %v", got, tt.want)
+			}
+
+			content, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(content, "# Doc\n\nIntro\n\n") {
+				t.Errorf("the introduction should be kept:\n%s", content)
+			}
+		})
+	}
+}
+
+func TestUnknownSectionIs(t *testing.T) {
+	doc := document()
+
+	err := Apply(&doc, InsertBefore("Missing", section("D")))
+	if !errors.Is(err, ErrUnknownSection) {
+		t.Errorf("Apply() error = %v, want ErrUnknownSection", err)
+	}
+}
+
+func TestEditsLeaveTheSourceUnchanged(t *testing.T) {
+	doc := document()
+	source := doc.Content
+
+	edited := doc
+	if err := Apply(&edited, Remove("B1"), Remove("C1"), InsertAfter("A", section("A1"))); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// The same content edited again must still have every section
+	again := doyoucompute.Document{Name: doc.Name, Content: source}
+	if got := Names(again); strings.Join(got, ",") != "A,B,B1,C,C1" {
+		t.Errorf("Names() of the source = %v, want [A B B1 C C1]", got)
+	}
+	if err := Apply(&again, Remove("B1"), Remove("C1")); err != nil {
+		t.Errorf("Apply() on the source again error = %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+// Package snippet extracts code samples from Go source files for use in documents.
+//
+// Samples live in compiled Go code, so they are type-checked by the normal build,
+// and only the relevant lines are published. A snippet can be the body of a
+// function or method, the body of an Example test function, or the lines between
+// a pair of marker comments:
+//
+//	// snippet:start options
+//	doc, err := bugreport.New(bugreport.WithName("Crash report"))
+//	// snippet:end options
+//
+// Basic usage:
+//
+//	//go:embed basics.go
+//	var sources embed.FS
+//
+//	sample, err := snippet.LoadRegion(sources, "basics.go", "options")
+//	if err != nil {
+//		// handle error
+//	}
+//	section.WriteCodeBlock("go", []string{sample.Code}, doyoucompute.Static)
+//
+// Sources already in memory are passed to Function, Example and Region directly.
+package snippet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/files"
+)
+
+// REGION_START and REGION_END are the comment prefixes that delimit a named region.
+const (
+	REGION_START = "snippet:start"
+	REGION_END   = "snippet:end"
+)
+
+// Snippet is a piece of Go source extracted from a file.
+type Snippet struct {
+	// File is the name of the file the snippet was extracted from
+	File string
+	// Name is the function, example or region name
+	Name string
+	// Code is the dedented source, without surrounding braces or markers
+	Code string
+}
+
+// CodeBlock returns the snippet as a go code block.
+func (s Snippet) CodeBlock() doyoucompute.CodeBlock {
+	return doyoucompute.CodeBlock{
+		BlockType: "go",
+		Cmd:       []string{s.Code},
+	}
+}
+
+// Function returns the body of the named function.
+// Methods are named with their receiver type, e.g. "Renderer.Render".
+func Function(filename string, src []byte, name string) (Snippet, error) {
+	fset, file, err := parse(filename, src)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	decl := findFunc(file, name)
+	if decl == nil || decl.Body == nil {
+		return Snippet{}, fmt.Errorf("function %s not found in %s", name, filename)
+	}
+
+	code := body(fset, src, decl.Body, nil)
+
+	return Snippet{File: filename, Name: name, Code: code}, nil
+}
+
+// Example returns the body of an Example test function, without its output comment.
+// The name may be given with or without the "Example" prefix, e.g. "New" or "ExampleNew".
+func Example(filename string, src []byte, name string) (Snippet, error) {
+	if !strings.HasPrefix(name, "Example") {
+		name = "Example" + name
+	}
+
+	fset, file, err := parse(filename, src)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	decl := findFunc(file, name)
+	if decl == nil || decl.Body == nil || decl.Recv != nil {
+		return Snippet{}, fmt.Errorf("example %s not found in %s", name, filename)
+	}
+
+	var output *ast.CommentGroup
+	for _, group := range file.Comments {
+		if group.Pos() < decl.Body.Lbrace || group.End() > decl.Body.Rbrace {
+			continue
+		}
+
+		text := strings.TrimSpace(group.Text())
+		if strings.HasPrefix(text, "Output:") || strings.HasPrefix(text, "Unordered output:") {
+			output = group
+		}
+	}
+
+	code := body(fset, src, decl.Body, output)
+
+	return Snippet{File: filename, Name: name, Code: code}, nil
+}
+
+// Region returns the lines between the "// snippet:start <name>" and "// snippet:end <name>" comments.
+// Other regions nested inside are included without their markers.
+func Region(filename string, src []byte, name string) (Snippet, error) {
+	fset, file, err := parse(filename, src)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	var (
+		start   = -1
+		end     = -1
+		markers = map[int]bool{}
+	)
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			marker, region, ok := parseMarker(comment.Text)
+			if !ok {
+				continue
+			}
+
+			line := fset.Position(comment.Pos()).Line
+			markers[line] = true
+
+			if region != name {
+				continue
+			}
+
+			switch {
+			case marker == REGION_START && start != -1:
+				return Snippet{}, fmt.Errorf("region %q in %s is started twice, on lines %d and %d", name, filename, start, line)
+			case marker == REGION_START:
+				start = line
+			case marker == REGION_END && start == -1:
+				return Snippet{}, fmt.Errorf("region %q in %s ends on line %d before it starts", name, filename, line)
+			case marker == REGION_END && end == -1:
+				end = line
+			}
+		}
+	}
+
+	if start == -1 {
+		return Snippet{}, fmt.Errorf("region %q not found in %s", name, filename)
+	}
+	if end == -1 {
+		return Snippet{}, fmt.Errorf("region %q in %s has no %s marker", name, filename, REGION_END)
+	}
+
+	lines := strings.Split(string(src), "\n")
+
+	var kept []string
+	for line := start + 1; line < end; line++ {
+		if markers[line] {
+			continue
+		}
+
+		kept = append(kept, lines[line-1])
+	}
+
+	return Snippet{File: filename, Name: name, Code: dedent(strings.Join(kept, "\n"))}, nil
+}
+
+func parse(filename string, src []byte) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse %s: %w", filename, err)
+	}
+
+	return fset, file, nil
+}
+
+// parseMarker splits a "// snippet:start name" comment into its marker and region name.
+func parseMarker(comment string) (string, string, bool) {
+	text := strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+
+	for _, marker := range []string{REGION_START, REGION_END} {
+		if rest, ok := strings.CutPrefix(text, marker); ok && (rest == "" || rest[0] == ' ') {
+			return marker, strings.TrimSpace(rest), true
+		}
+	}
+
+	return "", "", false
+}
+
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	receiver, method, isMethod := strings.Cut(name, ".")
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if !isMethod {
+			if fn.Recv == nil && fn.Name.Name == name {
+				return fn
+			}
+			continue
+		}
+
+		if fn.Recv != nil && fn.Name.Name == method && receiverName(fn.Recv) == receiver {
+			return fn
+		}
+	}
+
+	return nil
+}
+
+// receiverName returns the type name of a method receiver, without pointers or type parameters.
+func receiverName(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+
+	expr := recv.List[0].Type
+	for {
+		switch typed := expr.(type) {
+		case *ast.StarExpr:
+			expr = typed.X
+		case *ast.IndexExpr:
+			expr = typed.X
+		case *ast.IndexListExpr:
+			expr = typed.X
+		case *ast.Ident:
+			return typed.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// body returns the dedented source between the braces of block, stopping at cut when it is set.
+func body(fset *token.FileSet, src []byte, block *ast.BlockStmt, cut *ast.CommentGroup) string {
+	start := fset.Position(block.Lbrace).Offset + 1
+	end := fset.Position(block.Rbrace).Offset
+
+	if cut != nil {
+		end = fset.Position(cut.Pos()).Offset
+	}
+
+	return dedent(string(src[start:end]))
+}
+
+// dedent removes blank lines around the code, trailing whitespace,
+// and the indentation common to every non-blank line.
+func dedent(code string) string {
+	lines := strings.Split(code, "\n")
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	prefix, first := "", true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	for idx, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[idx] = ""
+			continue
+		}
+
+		lines[idx] = strings.TrimRight(strings.TrimPrefix(line, prefix), " \t")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// LoadFunction reads the file at path from fsys and returns the body of the named function.
+//
+// Example:
+//
+//	sample, err := snippet.LoadFunction(os.DirFS("."), "internal/samples/basics.go", "Basics")
+func LoadFunction(fsys fs.FS, path, name string) (Snippet, error) {
+	src, err := files.Read(fsys, path)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	return Function(path, src, name)
+}
+
+// LoadExample reads the file at path from fsys and returns the body of the named Example function.
+//
+// Example:
+//
+//	sample, err := snippet.LoadExample(os.DirFS("."), "pkg/readme/example_test.go", "New")
+func LoadExample(fsys fs.FS, path, name string) (Snippet, error) {
+	src, err := files.Read(fsys, path)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	return Example(path, src, name)
+}
+
+// LoadRegion reads the file at path from fsys and returns the named marker region.
+//
+// Example:
+//
+//	sample, err := snippet.LoadRegion(samples.Sources, "basics.go", "basics")
+func LoadRegion(fsys fs.FS, path, name string) (Snippet, error) {
+	src, err := files.Read(fsys, path)
+	if err != nil {
+		return Snippet{}, err
+	}
+
+	return Region(path, src, name)
+}
@@ -0,0 +1,281 @@
+package snippet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/files"
+)
+
+const source = `package sample
+
+import "fmt"
+
+type Renderer struct{}
+
+// Render prints the greeting.
+func (r *Renderer) Render(name string) {
+	// snippet:start greeting
+	greeting := fmt.Sprintf("hello %s", name)
+	// snippet:start inner
+	if name == "" {
+		greeting = "hello"
+	}
+	// snippet:end inner
+	// snippet:end greeting
+
+	fmt.Println(greeting)
+}
+
+func Basics() {
+	r := &Renderer{}
+
+	r.Render("world")
+}
+
+func OneLine() { fmt.Println("one") }
+
+func Unterminated() {
+	// snippet:start open
+	fmt.Println("open")
+}
+
+func Twice() {
+	// snippet:start twice
+	// snippet:start twice
+	// snippet:end twice
+}
+
+func Backwards() {
+	// snippet:end backwards
+	// snippet:start backwards
+}
+
+// snippet:start-not-a-marker
+const message = "// snippet:start string"
+`
+
+const exampleSource = `package sample_test
+
+import "fmt"
+
+func ExampleRenderer_Render() {
+	fmt.Println("hello world")
+
+	// Output:
+	// hello world
+}
+
+func ExampleNew() {
+	fmt.Println("no output")
+}
+`
+
+func TestFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "function body",
+			function: "Basics",
+			want:     "r := &Renderer{}\n\nr.Render(\"world\")",
+		},
+		{
+			name:     "single line body",
+			function: "OneLine",
+			want:     "fmt.Println(\"one\")",
+		},
+		{
+			name:     "method with receiver",
+			function: "Renderer.Render",
+			want:     "// snippet:start greeting\ngreeting := fmt.Sprintf(\"hello %s\", name)",
+		},
+		{
+			name:     "method without receiver is not a function",
+			function: "Render",
+			wantErr:  "function Render not found in sample.go",
+		},
+		{
+			name:     "missing function",
+			function: "Missing",
+			wantErr:  "function Missing not found in sample.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Function("sample.go", []byte(source), tt.function)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Function() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Function() error = %v", err)
+			}
+
+			if !strings.HasPrefix(got.Code, tt.want) {
+				t.Errorf("Function() = %q, should start with %q", got.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestExample(t *testing.T) {
+	tests := []struct {
+		name    string
+		example string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "output comment is dropped",
+			example: "ExampleRenderer_Render",
+			want:    "fmt.Println(\"hello world\")",
+		},
+		{
+			name:    "prefix is optional",
+			example: "New",
+			want:    "fmt.Println(\"no output\")",
+		},
+		{
+			name:    "missing example",
+			example: "Missing",
+			wantErr: "example ExampleMissing not found in sample_test.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Example("sample_test.go", []byte(exampleSource), tt.example)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Example() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Example() error = %v", err)
+			}
+
+			if got.Code != tt.want {
+				t.Errorf("Example() = %q, want %q", got.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "nested markers are removed",
+			region: "greeting",
+			want:   "greeting := fmt.Sprintf(\"hello %s\", name)\nif name == \"\" {\n\tgreeting = \"hello\"\n}",
+		},
+		{
+			name:   "inner region",
+			region: "inner",
+			want:   "if name == \"\" {\n\tgreeting = \"hello\"\n}",
+		},
+		{
+			name:    "markers in strings are ignored",
+			region:  "string",
+			wantErr: "region \"string\" not found in sample.go",
+		},
+		{
+			name:    "missing end marker",
+			region:  "open",
+			wantErr: "region \"open\" in sample.go has no snippet:end marker",
+		},
+		{
+			name:    "started twice",
+			region:  "twice",
+			wantErr: "is started twice, on lines",
+		},
+		{
+			name:    "end before start",
+			region:  "backwards",
+			wantErr: "ends on line 41 before it starts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Region("sample.go", []byte(source), tt.region)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Region() error = %v, should contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Region() error = %v", err)
+			}
+
+			if got.Code != tt.want {
+				t.Errorf("Region() = %q, want %q", got.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Function("broken.go", []byte("package broken\n\nfunc {"), "Broken")
+	if err == nil || !strings.Contains(err.Error(), "could not parse broken.go") {
+		t.Errorf("Function() error = %v, want parse error", err)
+	}
+}
+
+func TestCodeBlock(t *testing.T) {
+	block := Snippet{Code: "fmt.Println(\"hi\")"}.CodeBlock()
+
+	if block.BlockType != "go" || len(block.Cmd) != 1 || block.Cmd[0] != "fmt.Println(\"hi\")" {
+		t.Errorf("CodeBlock() = %+v", block)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"samples/sample.go":      {Data: []byte(source)},
+		"samples/sample_test.go": {Data: []byte(exampleSource)},
+	}
+
+	function, err := LoadFunction(fsys, "samples/sample.go", "OneLine")
+	if err != nil || function.Code != "fmt.Println(\"one\")" {
+		t.Errorf("LoadFunction() = %q, %v", function.Code, err)
+	}
+	if function.File != "samples/sample.go" {
+		t.Errorf("LoadFunction() file = %q, want samples/sample.go", function.File)
+	}
+
+	example, err := LoadExample(fsys, "samples/sample_test.go", "New")
+	if err != nil || example.Code != "fmt.Println(\"no output\")" {
+		t.Errorf("LoadExample() = %q, %v", example.Code, err)
+	}
+
+	region, err := LoadRegion(fsys, "samples/sample.go", "inner")
+	if err != nil || !strings.HasPrefix(region.Code, "if name == \"\" {") {
+		t.Errorf("LoadRegion() = %q, %v", region.Code, err)
+	}
+
+	_, err = LoadRegion(fsys, "samples/missing.go", "inner")
+
+	var missing *files.MissingFileError
+	if !errors.As(err, &missing) {
+		t.Fatalf("LoadRegion() error = %v, want *files.MissingFileError", err)
+	}
+	if !strings.Contains(err.Error(), `"samples/missing.go" not found in fstest.MapFS`) {
+		t.Errorf("LoadRegion() error = %v, should name the path and file system", err)
+	}
+}
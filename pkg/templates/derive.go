@@ -0,0 +1,292 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+)
+
+// DerivedProps holds the changes a derived template makes to the document its base builds.
+type DerivedProps struct {
+	// Default path, inherited from the base when empty
+	DefaultPath string
+	// Description, inherited from the base when empty
+	Description string
+	// Document name, inherited from the base when empty
+	DocumentName string
+	// Frontmatter values set over the base's frontmatter
+	Frontmatter map[string]interface{}
+	// Section edits applied after the base's sections are in place
+	Edits []sections.Edit
+}
+
+// DeriveOption changes what a derived template changes about its base.
+type DeriveOption = doyoucompute.OptionBuilder[DerivedProps]
+
+// WithDefaultPath overrides the path the derived document is rendered to.
+//
+// Example:
+//
+//	templates.WithDefaultPath(".github/ISSUE_TEMPLATE/internal_bug_report.md")
+func WithDefaultPath(path string) DeriveOption {
+	return func(p *DerivedProps) (doyoucompute.Finalizer[DerivedProps], error) {
+		if path == "" {
+			return nil, errors.New("default path cannot be empty")
+		}
+
+		p.DefaultPath = path
+
+		return nil, nil
+	}
+}
+
+// WithDescription overrides the description of the derived template.
+//
+// Example:
+//
+//	templates.WithDescription("Bug report for internal services, routed to the platform team")
+func WithDescription(description string) DeriveOption {
+	return func(p *DerivedProps) (doyoucompute.Finalizer[DerivedProps], error) {
+		p.Description = description
+
+		return nil, nil
+	}
+}
+
+// WithDocumentName overrides the name of the derived document, along with the name
+// in its frontmatter when the base sets one, as issue templates do.
+//
+// Example:
+//
+//	templates.WithDocumentName("Internal bug report")
+func WithDocumentName(name string) DeriveOption {
+	return func(p *DerivedProps) (doyoucompute.Finalizer[DerivedProps], error) {
+		if name == "" {
+			return nil, errors.New("document name cannot be empty")
+		}
+
+		p.DocumentName = name
+
+		return nil, nil
+	}
+}
+
+// WithFrontmatter sets frontmatter values over those of the base, keeping the rest.
+// Later calls win over earlier ones for the same key.
+//
+// Example:
+//
+//	templates.WithFrontmatter(map[string]interface{}{"labels": "bug,internal", "assignees": "platform-oncall"})
+func WithFrontmatter(values map[string]interface{}) DeriveOption {
+	return func(p *DerivedProps) (doyoucompute.Finalizer[DerivedProps], error) {
+		if p.Frontmatter == nil {
+			p.Frontmatter = map[string]interface{}{}
+		}
+
+		maps.Copy(p.Frontmatter, values)
+
+		return nil, nil
+	}
+}
+
+// WithSections adds, moves or removes sections of the base document.
+// Edits naming a section the base does not have fail the build with sections.ErrUnknownSection.
+//
+// Example:
+//
+//	templates.WithSections(
+//		sections.InsertAfter("Environment details", serviceSection),
+//		sections.Remove("Code Samples"),
+//	)
+func WithSections(edits ...sections.Edit) DeriveOption {
+	return func(p *DerivedProps) (doyoucompute.Finalizer[DerivedProps], error) {
+		p.Edits = append(p.Edits, edits...)
+
+		return nil, nil
+	}
+}
+
+type derived struct {
+	name  string
+	base  Template
+	props DerivedProps
+}
+
+// Derive returns a template named name that builds the same document as base and then
+// applies the options' changes to it. Derived templates can be derived from in turn,
+// each applying its changes after those of the template it derives from, and are
+// registered and validated by Documents like any other template. The base is built
+// from its settings in the config when the config lists it, and from empty settings
+// otherwise; set skip on the base to build a variant from its settings without
+// generating the base itself.
+//
+// Example:
+//
+//	internal, err := templates.Derive(templates.BugReport(), "internal-bugreport",
+//		templates.WithDefaultPath(".github/ISSUE_TEMPLATE/internal_bug_report.md"),
+//		templates.WithSections(sections.Remove("Code Samples")),
+//	)
+func Derive(base Template, name string, opts ...DeriveOption) (Template, error) {
+	if base == nil {
+		return nil, errors.New("base template cannot be nil")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("derived template name cannot be empty")
+	}
+	if name == base.Name() {
+		return nil, fmt.Errorf("derived template must be named differently from its base %q", base.Name())
+	}
+
+	props := DerivedProps{}
+
+	if err := doyoucompute.ApplyOptions(&props, opts...); err != nil {
+		return nil, fmt.Errorf("could not derive %s from %s: %w", name, base.Name(), err)
+	}
+
+	return derived{name: name, base: base, props: props}, nil
+}
+
+func (t derived) Name() string { return t.name }
+
+func (t derived) DefaultPath() string {
+	if t.props.DefaultPath != "" {
+		return t.props.DefaultPath
+	}
+
+	return t.base.DefaultPath()
+}
+
+func (t derived) Description() string {
+	if t.props.Description != "" {
+		return t.props.Description
+	}
+
+	return t.base.Description()
+}
+
+// Build builds the base from its settings in cfg, or from empty settings when cfg leaves
+// the base out, so a derived template is generated whether or not its base is.
+func (t derived) Build(cfg *config.Config) (doyoucompute.Document, error) {
+	doc, err := t.base.Build(cfg.WithDefaults())
+	if err != nil {
+		return doyoucompute.Document{}, err
+	}
+
+	if len(t.props.Frontmatter) > 0 || (t.props.DocumentName != "" && doc.Frontmatter.Data["name"] != nil) {
+		// Copy the frontmatter so the base document's data is left as it was
+		data := maps.Clone(doc.Frontmatter.Data)
+		if data == nil {
+			data = map[string]interface{}{}
+		}
+
+		if t.props.DocumentName != "" && data["name"] != nil {
+			data["name"] = t.props.DocumentName
+		}
+
+		maps.Copy(data, t.props.Frontmatter)
+		doc.Frontmatter = *doyoucompute.NewFrontmatter(data)
+	}
+
+	if t.props.DocumentName != "" {
+		doc.Name = t.props.DocumentName
+	}
+
+	if err := sections.Apply(&doc, t.props.Edits...); err != nil {
+		return doyoucompute.Document{}, fmt.Errorf("%s derived from %s: %w", t.name, t.base.Name(), err)
+	}
+
+	return doc, nil
+}
+
+// Configured returns the built-in templates followed by the templates derived from them
+// under derived in cfg, in the order they are declared, so the documents a config file
+// declares can be built with Documents(cfg, Configured(cfg)...). Each derived template
+// may derive from a built-in template or from one declared before it.
+// Returns a *config.ValidationError listing every derived template that could not be declared.
+//
+// Example:
+//
+//	ts, err := templates.Configured(cfg)
+//	if err != nil {
+//		// handle error
+//	}
+//	docs, err := templates.Documents(cfg, ts...)
+func Configured(cfg *config.Config) ([]Template, error) {
+	ts := Builtin()
+	if cfg == nil {
+		return ts, nil
+	}
+
+	invalid := &config.ValidationError{File: cfg.File()}
+
+	for idx, declared := range cfg.Derived {
+		path := fmt.Sprintf("derived[%d]", idx)
+
+		base, err := Lookup(declared.Base, ts...)
+		if err != nil {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt(path+".base", err))
+			continue
+		}
+
+		if _, err := Lookup(declared.Template, ts...); err == nil {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt(path+".template", fmt.Errorf("template %q is already declared", declared.Template)))
+			continue
+		}
+
+		opts := []DeriveOption{WithDescription(declared.Description)}
+
+		if declared.Path != "" {
+			opts = append(opts, WithDefaultPath(declared.Path))
+		}
+		if declared.Name != "" {
+			opts = append(opts, WithDocumentName(declared.Name))
+		}
+		if len(declared.Frontmatter) > 0 {
+			values := map[string]interface{}{}
+			for _, value := range declared.Frontmatter {
+				values[value.Key] = value.Value
+			}
+			opts = append(opts, WithFrontmatter(values))
+		}
+		for _, section := range declared.Sections {
+			opts = append(opts, WithSections(sections.Append(section.Build())))
+		}
+		for _, name := range declared.Disable {
+			opts = append(opts, WithSections(sections.RemoveAll(name)))
+		}
+
+		t, err := Derive(base, declared.Template, opts...)
+		if err != nil {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt(path, err))
+			continue
+		}
+
+		ts = append(ts, positioned{Template: t, cfg: cfg, path: path})
+	}
+
+	if len(invalid.Errors) > 0 {
+		return nil, invalid
+	}
+
+	return ts, nil
+}
+
+// positioned points the build errors of a template declared in a config file at its declaration.
+type positioned struct {
+	Template
+	cfg  *config.Config
+	path string
+}
+
+func (t positioned) Build(cfg *config.Config) (doyoucompute.Document, error) {
+	doc, err := t.Template.Build(cfg)
+	if err != nil {
+		return doyoucompute.Document{}, t.cfg.ErrorAt(t.path, err)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,393 @@
+package templates
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/labels"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/sections"
+)
+
+func internalBugReport(t *testing.T) Template {
+	t.Helper()
+
+	service := doyoucompute.NewSection("Affected service")
+	service.WriteComment("Which service and environment is affected?")
+
+	incident := doyoucompute.NewSection("Incident")
+	incident.WriteComment("Link the incident, if there is one.")
+
+	internal, err := Derive(BugReportFrom(bugreport.WithLabels(labels.Bug())), "internal-bugreport",
+		WithDefaultPath(".github/ISSUE_TEMPLATE/internal_bug_report.md"),
+		WithDescription("Bug report for internal services"),
+		WithDocumentName("Internal bug report"),
+		WithFrontmatter(map[string]interface{}{"assignees": "platform-oncall"}),
+		WithSections(
+			sections.InsertAfter("Environment details", service),
+			sections.Append(incident),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+
+	return internal
+}
+
+func TestDerive(t *testing.T) {
+	internal := internalBugReport(t)
+
+	payments, err := Derive(internal, "payments-bugreport",
+		WithDocumentName("Payments bug report"),
+		WithFrontmatter(map[string]interface{}{"assignees": "payments-oncall"}),
+		WithSections(sections.Remove("Code Samples")),
+	)
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		template        Template
+		wantPath        string
+		wantDescription string
+		wantDocument    string
+		wantFrontmatter map[string]interface{}
+		wantSections    []string
+	}{
+		{
+			name:            "derived from a built-in template",
+			template:        internal,
+			wantPath:        ".github/ISSUE_TEMPLATE/internal_bug_report.md",
+			wantDescription: "Bug report for internal services",
+			wantDocument:    "Internal bug report",
+			wantFrontmatter: map[string]interface{}{
+				"name":      "Internal bug report",
+				"about":     "Report a bug",
+				"title":     "",
+				"labels":    "bug",
+				"assignees": "platform-oncall",
+			},
+			wantSections: []string{"Expected behavior", "Actual behavior", "Environment details", "Affected service", "Steps to reproduce", "Code Samples", "Error Messages", "Incident"},
+		},
+		{
+			name:            "derived from a derived template",
+			template:        payments,
+			wantPath:        ".github/ISSUE_TEMPLATE/internal_bug_report.md",
+			wantDescription: "Bug report for internal services",
+			wantDocument:    "Payments bug report",
+			wantFrontmatter: map[string]interface{}{
+				"name":      "Payments bug report",
+				"about":     "Report a bug",
+				"title":     "",
+				"labels":    "bug",
+				"assignees": "payments-oncall",
+			},
+			wantSections: []string{"Expected behavior", "Actual behavior", "Environment details", "Affected service", "Steps to reproduce", "Error Messages", "Incident"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.template.DefaultPath(); got != tt.wantPath {
+				t.Errorf("DefaultPath() = %q, want %q", got, tt.wantPath)
+			}
+			if got := tt.template.Description(); got != tt.wantDescription {
+				t.Errorf("Description() = %q, want %q", got, tt.wantDescription)
+			}
+
+			doc, err := tt.template.Build(nil)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			if doc.Name != tt.wantDocument {
+				t.Errorf("Build().Name = %q, want %q", doc.Name, tt.wantDocument)
+			}
+			if !reflect.DeepEqual(doc.Frontmatter.Data, tt.wantFrontmatter) {
+				t.Errorf("Build().Frontmatter = %v, want %v", doc.Frontmatter.Data, tt.wantFrontmatter)
+			}
+			if got := sections.Names(doc); !reflect.DeepEqual(got, tt.wantSections) {
+				t.Errorf("sections.Names() = %q, want %q", got, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    Template
+		derived string
+		opts    []DeriveOption
+		wantErr string
+	}{
+		{
+			name:    "missing base",
+			derived: "internal-bugreport",
+			wantErr: "base template cannot be nil",
+		},
+		{
+			name:    "missing name",
+			base:    BugReport(),
+			wantErr: "derived template name cannot be empty",
+		},
+		{
+			name:    "same name as the base",
+			base:    BugReport(),
+			derived: "bugreport",
+			wantErr: `derived template must be named differently from its base "bugreport"`,
+		},
+		{
+			name:    "invalid option",
+			base:    BugReport(),
+			derived: "internal-bugreport",
+			opts:    []DeriveOption{WithDefaultPath("")},
+			wantErr: "could not derive internal-bugreport from bugreport: default path cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Derive(tt.base, tt.derived, tt.opts...)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Derive() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDerivedDocuments(t *testing.T) {
+	internal := internalBugReport(t)
+
+	unknownSection, err := Derive(internal, "broken-bugreport", WithSections(sections.Remove("Screenshots")))
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+
+	if _, err := unknownSection.Build(nil); !errors.Is(err, sections.ErrUnknownSection) {
+		t.Errorf("Build() error = %v, want sections.ErrUnknownSection", err)
+	}
+
+	fromConfig, err := Derive(BugReport(), "team-bugreport",
+		WithDefaultPath(".github/ISSUE_TEMPLATE/team_bug_report.md"),
+		WithDocumentName("Team bug report"),
+	)
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		config    string
+		templates []Template
+		wantPaths []string
+		wantErr   string
+	}{
+		{
+			name:      "registered alongside the built-in templates",
+			config:    "templates:\n  bugreport: {}\n",
+			templates: append(Builtin(), internal),
+			wantPaths: []string{".github/ISSUE_TEMPLATE/bug_report.md", ".github/ISSUE_TEMPLATE/internal_bug_report.md"},
+		},
+		{
+			name:      "derived from a configured template",
+			config:    "templates:\n  bugreport:\n    disable: [Code Samples]\n",
+			templates: []Template{BugReport(), fromConfig},
+			wantPaths: []string{".github/ISSUE_TEMPLATE/bug_report.md", ".github/ISSUE_TEMPLATE/team_bug_report.md"},
+		},
+		{
+			name:      "built from default settings when the config leaves the base out",
+			config:    "templates:\n  pullrequest: {}\n",
+			templates: append(Builtin(), fromConfig),
+			wantPaths: []string{".github/PULL_REQUEST_TEMPLATE.md", ".github/ISSUE_TEMPLATE/team_bug_report.md"},
+		},
+		{
+			name:      "built from a skipped base",
+			config:    "templates:\n  bugreport:\n    skip: true\n    disable: [Code Samples]\n",
+			templates: append(Builtin(), fromConfig),
+			wantPaths: []string{".github/ISSUE_TEMPLATE/team_bug_report.md"},
+		},
+		{
+			name:      "unknown section",
+			config:    "templates:\n  pullrequest: {}\n",
+			templates: []Template{unknownSection},
+			wantErr:   `templates.broken-bugreport: broken-bugreport derived from internal-bugreport: unknown section "Screenshots" in Internal bug report`,
+		},
+		{
+			name:      "listed twice",
+			config:    "templates:\n  pullrequest: {}\n",
+			templates: []Template{internal, internal},
+			wantErr:   `templates.internal-bugreport: template "internal-bugreport" is listed more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config.Parse() error = %v", err)
+			}
+
+			docs, err := Documents(cfg, tt.templates...)
+			if tt.wantErr != "" {
+				var invalid *config.ValidationError
+				if !errors.As(err, &invalid) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Documents() error = %v, should be a *config.ValidationError containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Documents() error = %v", err)
+			}
+
+			var paths []string
+			for _, entry := range docs.Entries() {
+				paths = append(paths, entry.Path)
+			}
+
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Documents() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestConfigured(t *testing.T) {
+	const derived = `templates:
+  bugreport:
+    skip: true
+    labels: [bug]
+derived:
+  - template: internal-bugreport
+    base: bugreport
+    path: .github/ISSUE_TEMPLATE/internal_bug_report.md
+    name: Internal bug report
+    description: Bug report for internal services
+    frontmatter:
+      - key: assignees
+        value: platform-oncall
+    sections:
+      - name: Affected service
+        markdown: Which service and environment is affected?
+    disable: [Code Samples]
+  - template: payments-bugreport
+    base: internal-bugreport
+    path: .github/ISSUE_TEMPLATE/payments_bug_report.md
+    name: Payments bug report
+  - template: change-request
+    base: pullrequest
+    path: docs/CHANGE_REQUEST.md
+`
+
+	cfg, err := config.Parse([]byte(derived))
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+
+	ts, err := Configured(cfg)
+	if err != nil {
+		t.Fatalf("Configured() error = %v", err)
+	}
+
+	docs, err := Documents(cfg, ts...)
+	if err != nil {
+		t.Fatalf("Documents() error = %v", err)
+	}
+
+	var paths []string
+	for _, entry := range docs.Entries() {
+		paths = append(paths, entry.Path)
+	}
+
+	want := []string{".github/ISSUE_TEMPLATE/internal_bug_report.md", ".github/ISSUE_TEMPLATE/payments_bug_report.md", "docs/CHANGE_REQUEST.md"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("Documents() paths = %v, want %v", paths, want)
+	}
+
+	payments := docs.Entries()[1].Document
+	if payments.Name != "Payments bug report" || payments.Frontmatter.Data["name"] != "Payments bug report" {
+		t.Errorf("document name = %q, frontmatter name = %v, want Payments bug report", payments.Name, payments.Frontmatter.Data["name"])
+	}
+	if payments.Frontmatter.Data["assignees"] != "platform-oncall" || payments.Frontmatter.Data["labels"] != "bug" {
+		t.Errorf("frontmatter = %v, should keep the base's labels and the internal assignees", payments.Frontmatter.Data)
+	}
+
+	names := sections.Names(payments)
+	if !slices.Contains(names, "Affected service") || slices.Contains(names, "Code Samples") {
+		t.Errorf("sections.Names() = %v, should have Affected service and no Code Samples", names)
+	}
+
+	internal, err := Lookup("internal-bugreport", ts...)
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if internal.Description() != "Bug report for internal services" {
+		t.Errorf("Description() = %q", internal.Description())
+	}
+}
+
+func TestConfiguredErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "unknown base",
+			config:  "templates: {}\nderived:\n  - template: security\n    base: securitypolicy\n",
+			wantErr: `4:5: derived[0].base: unknown template "securitypolicy", expected one of readme, contributing, pullrequest, bugreport`,
+		},
+		{
+			name:    "base declared later",
+			config:  "templates: {}\nderived:\n  - template: payments\n    base: internal\n  - template: internal\n    base: bugreport\n",
+			wantErr: `4:5: derived[0].base: unknown template "internal"`,
+		},
+		{
+			name:    "name of a built-in template",
+			config:  "templates: {}\nderived:\n  - template: bugreport\n    base: pullrequest\n",
+			wantErr: `3:5: derived[0].template: template "bugreport" is already declared`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config.Parse() error = %v", err)
+			}
+
+			_, err = Configured(cfg)
+
+			var invalid *config.ValidationError
+			if !errors.As(err, &invalid) || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("Configured() error = %v, should be a *config.ValidationError starting with %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfiguredBuildErrors(t *testing.T) {
+	cfg, err := config.Parse([]byte("templates: {}\nderived:\n  - template: internal\n    base: bugreport\n    disable: [Screenshots]\n"))
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+
+	ts, err := Configured(cfg)
+	if err != nil {
+		t.Fatalf("Configured() error = %v", err)
+	}
+
+	_, err = Documents(cfg, ts...)
+
+	want := `3:5: derived[0]: internal derived from bugreport: unknown section "Screenshots" in Bug Report`
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Documents() error = %v, want prefix %q", err, want)
+	}
+}
@@ -0,0 +1,241 @@
+// Package templates gives every document template the same shape.
+//
+// The template packages have constructors of different shapes: readme.New takes
+// props and extra sections, contributing.New takes the project and issue tracker
+// urls, and bugreport.New and pullrequest.New take only options. A Template hides
+// those differences behind a name, a default path, a description and a Build
+// method reading its settings from a config.Config, so registries, CLIs and config
+// loaders can handle any template, built-in or not, in the same way.
+//
+// Basic usage:
+//
+//	cfg, err := config.Load(os.DirFS("."), config.DEFAULT_PATH)
+//	if err != nil {
+//		// handle error
+//	}
+//	docs, err := templates.Documents(cfg, templates.Builtin()...)
+//	if err != nil {
+//		// handle error
+//	}
+//
+// Adapting a constructor that needs no settings:
+//
+//	codeOfConduct := templates.New(
+//		"codeofconduct",
+//		"CODE_OF_CONDUCT.md",
+//		"Code of conduct for contributors",
+//		func(*config.Config) (doyoucompute.Document, error) {
+//			return newCodeOfConduct()
+//		},
+//	)
+//	docs, err := templates.Documents(cfg, append(templates.Builtin(), codeOfConduct)...)
+//
+// Deriving an organisation's bug report, and a team's variant of it, from the built-in one:
+//
+//	internal, err := templates.Derive(templates.BugReportFrom(bugreport.WithLabels(labels.Bug())), "internal-bugreport",
+//		templates.WithDefaultPath(".github/ISSUE_TEMPLATE/internal_bug_report.md"),
+//		templates.WithDocumentName("Internal bug report"),
+//		templates.WithSections(
+//			sections.InsertAfter("Environment details", affectedServiceSection),
+//			sections.Append(incidentSection),
+//		),
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//	payments, err := templates.Derive(internal, "payments-bugreport",
+//		templates.WithDocumentName("Payments bug report"),
+//		templates.WithFrontmatter(map[string]interface{}{"assignees": "payments-oncall"}),
+//	)
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/bugreport"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/contributing"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/pullrequest"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/readme"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/registry"
+)
+
+// Template builds one kind of document.
+type Template interface {
+	// Name identifies the template, e.g. "readme"; built-in templates use their key under templates in the config
+	Name() string
+	// DefaultPath is where the document is rendered unless the config says otherwise
+	DefaultPath() string
+	// Description says in one sentence what the document is for
+	Description() string
+	// Build builds the document from the settings in cfg, returning config.ErrNotConfigured
+	// when cfg leaves the template out
+	Build(cfg *config.Config) (doyoucompute.Document, error)
+}
+
+// BuildFunc builds a document from the settings in cfg.
+type BuildFunc func(cfg *config.Config) (doyoucompute.Document, error)
+
+type template struct {
+	name        string
+	defaultPath string
+	description string
+	build       BuildFunc
+}
+
+func (t template) Name() string        { return t.name }
+func (t template) DefaultPath() string { return t.defaultPath }
+func (t template) Description() string { return t.description }
+
+func (t template) Build(cfg *config.Config) (doyoucompute.Document, error) {
+	return t.build(cfg)
+}
+
+// New returns a template built by build.
+//
+// Example:
+//
+//	templates.New("security", "SECURITY.md", "How to report vulnerabilities", buildSecurityPolicy)
+func New(name, defaultPath, description string, build BuildFunc) Template {
+	return template{name: name, defaultPath: defaultPath, description: description, build: build}
+}
+
+// fromConfig adapts a built-in template, which config.Config builds with its package's New function.
+func fromConfig(name, defaultPath, description string) Template {
+	return New(name, defaultPath, description, func(cfg *config.Config) (doyoucompute.Document, error) {
+		return cfg.Build(name)
+	})
+}
+
+// Readme returns the README template, built with readme.New from templates.readme.
+func Readme() Template {
+	return fromConfig("readme", readme.DEFAULT_PATH, "Introduction, features and quick start for the project")
+}
+
+// Contributing returns the contributing guide template, built with contributing.New from templates.contributing.
+func Contributing() Template {
+	return fromConfig("contributing", contributing.DEFAULT_PATH, "How to set up, change and submit contributions to the project")
+}
+
+// PullRequest returns the pull request template, built with pullrequest.New from templates.pullrequest.
+func PullRequest() Template {
+	return fromConfig("pullrequest", pullrequest.DEFAULT_PATH, "Description, related issue and testing notes asked of every pull request")
+}
+
+// BugReport returns the bug report issue template, built with bugreport.New from templates.bugreport.
+func BugReport() Template {
+	return fromConfig("bugreport", bugreport.DEFAULT_PATH, "Expected and actual behavior, environment and reproduction steps asked of every bug report")
+}
+
+// ReadmeFrom returns the README template built with readme.New from the arguments given, ignoring the config.
+func ReadmeFrom(props readme.ReadmeProps, additionalSections []doyoucompute.Section, opts ...readme.Option) Template {
+	return New(Readme().Name(), readme.DEFAULT_PATH, Readme().Description(), func(*config.Config) (doyoucompute.Document, error) {
+		return readme.New(props, additionalSections, opts...)
+	})
+}
+
+// ContributingFrom returns the contributing guide template built with contributing.New from the arguments given, ignoring the config.
+func ContributingFrom(projectUrl, issueTrackerUrl string, opts ...contributing.Option) Template {
+	return New(Contributing().Name(), contributing.DEFAULT_PATH, Contributing().Description(), func(*config.Config) (doyoucompute.Document, error) {
+		return contributing.New(projectUrl, issueTrackerUrl, opts...)
+	})
+}
+
+// PullRequestFrom returns the pull request template built with pullrequest.New from the options given, ignoring the config.
+func PullRequestFrom(opts ...pullrequest.Option) Template {
+	return New(PullRequest().Name(), pullrequest.DEFAULT_PATH, PullRequest().Description(), func(*config.Config) (doyoucompute.Document, error) {
+		return pullrequest.New(opts...)
+	})
+}
+
+// BugReportFrom returns the bug report template built with bugreport.New from the options given, ignoring the config.
+func BugReportFrom(opts ...bugreport.Option) Template {
+	return New(BugReport().Name(), bugreport.DEFAULT_PATH, BugReport().Description(), func(*config.Config) (doyoucompute.Document, error) {
+		return bugreport.New(opts...)
+	})
+}
+
+// Builtin returns the built-in templates, in the order config.TemplateNames lists them.
+func Builtin() []Template {
+	return []Template{Readme(), Contributing(), PullRequest(), BugReport()}
+}
+
+// Lookup returns the template named name from ts.
+func Lookup(name string, ts ...Template) (Template, error) {
+	names := make([]string, 0, len(ts))
+
+	for _, t := range ts {
+		if t.Name() == name {
+			return t, nil
+		}
+
+		names = append(names, t.Name())
+	}
+
+	return nil, fmt.Errorf("unknown template %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Path returns where t is rendered: the path configured for it in cfg, or else its default path.
+// Paths are looked up by name, so a path configured for a built-in template also applies to
+// the template returned by the matching From adapter.
+func Path(cfg *config.Config, t Template) string {
+	if path := cfg.Path(t.Name()); path != "" {
+		return path
+	}
+
+	return t.DefaultPath()
+}
+
+// Documents builds every template in ts that cfg does not leave out, in order, and
+// registers each at its path, except those cfg marks to skip. Returns a *config.ValidationError listing every template
+// that could not be built, positioned in the config file where possible.
+// A nil cfg builds only the templates that need no settings.
+func Documents(cfg *config.Config, ts ...Template) (*registry.Registry, error) {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	docs, err := registry.New()
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := &config.ValidationError{File: cfg.File()}
+
+	seen := map[string]bool{}
+
+	for _, t := range ts {
+		if seen[t.Name()] {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt("templates."+t.Name(), fmt.Errorf("template %q is listed more than once", t.Name())))
+			continue
+		}
+		seen[t.Name()] = true
+
+		doc, err := t.Build(cfg)
+		if errors.Is(err, config.ErrNotConfigured) {
+			continue
+		}
+		if err != nil {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt("templates."+t.Name(), err))
+			continue
+		}
+
+		// Skipped templates are still built, so their settings are checked
+		if cfg.Skipped(t.Name()) {
+			continue
+		}
+
+		if err := docs.Add(registry.Entry{Path: Path(cfg, t), Document: doc}); err != nil {
+			invalid.Errors = append(invalid.Errors, cfg.ErrorAt("templates."+t.Name()+".path", err))
+		}
+	}
+
+	if len(invalid.Errors) > 0 {
+		return nil, invalid
+	}
+
+	return docs, nil
+}
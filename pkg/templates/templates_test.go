@@ -0,0 +1,361 @@
+package templates
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+)
+
+const FULL_CONFIG = `templates:
+  readme:
+    name: widget
+    intro: Widgets for everyone.
+    features: |
+      - Fast
+      - Small
+    quickstart: |
+      Run ` + "`go get github.com/acme/widget`" + `.
+    license:
+      name: MIT
+    sections:
+      - name: Usage
+        markdown: Call widget.New.
+    table_of_contents: 1
+  contributing:
+    path: docs/CONTRIBUTING.md
+    project_url: https://github.com/acme/widget
+    preset: internal
+    default_branch: develop
+    sign_off: true
+    dco: true
+    disable: [Writing documentation]
+  pullrequest:
+    name: Change request
+    sections:
+      - name: Security impact
+        markdown: Does this change how credentials are handled?
+  bugreport:
+    about: Report a widget bug
+    labels: [bug]
+    assignees: [octocat, hubot]
+    disable: [Code Samples]
+`
+
+func render(t *testing.T, doc doyoucompute.Document) string {
+	t.Helper()
+
+	content, err := doyoucompute.NewMarkdownRenderer().Render(&doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	return content
+}
+
+func securityPolicy() Template {
+	return New("security", "SECURITY.md", "How to report vulnerabilities", func(*config.Config) (doyoucompute.Document, error) {
+		return doyoucompute.DocumentFactory("Security policy", func(d *doyoucompute.Document) error {
+			section := d.CreateSection("Reporting a vulnerability")
+			section.WriteIntro().Text("Email security@example.com.")
+
+			return nil
+		})
+	})
+}
+
+func failing() Template {
+	return New("failing", "FAILING.md", "Always fails", func(*config.Config) (doyoucompute.Document, error) {
+		return doyoucompute.Document{}, errors.New("could not build")
+	})
+}
+
+func TestBuiltin(t *testing.T) {
+	var names []string
+
+	for _, template := range Builtin() {
+		names = append(names, template.Name())
+
+		if template.DefaultPath() != config.DefaultPaths()[template.Name()] {
+			t.Errorf("%s.DefaultPath() = %q, want %q", template.Name(), template.DefaultPath(), config.DefaultPaths()[template.Name()])
+		}
+		if template.Description() == "" {
+			t.Errorf("%s.Description() is empty", template.Name())
+		}
+	}
+
+	if !reflect.DeepEqual(names, config.TemplateNames()) {
+		t.Errorf("Builtin() names = %v, want %v", names, config.TemplateNames())
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		lookup  string
+		wantErr string
+	}{
+		{
+			name:   "built-in template",
+			lookup: "contributing",
+		},
+		{
+			name:   "custom template",
+			lookup: "security",
+		},
+		{
+			name:    "unknown template",
+			lookup:  "codeofconduct",
+			wantErr: `unknown template "codeofconduct", expected one of readme, contributing, pullrequest, bugreport, security`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(tt.lookup, append(Builtin(), securityPolicy())...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Lookup() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+
+			if got.Name() != tt.lookup {
+				t.Errorf("Lookup().Name() = %q, want %q", got.Name(), tt.lookup)
+			}
+		})
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		templates []Template
+		wantPaths []string
+		wantErr   string
+	}{
+		{
+			name:      "templates left out of the config are skipped",
+			config:    "templates:\n  pullrequest:\n    path: docs/PULL_REQUEST_TEMPLATE.md\n",
+			templates: Builtin(),
+			wantPaths: []string{"docs/PULL_REQUEST_TEMPLATE.md"},
+		},
+		{
+			name:      "custom templates need no settings",
+			config:    "templates:\n  bugreport: {}\n",
+			templates: append(Builtin(), securityPolicy()),
+			wantPaths: []string{".github/ISSUE_TEMPLATE/bug_report.md", "SECURITY.md"},
+		},
+		{
+			name:      "config errors keep their position",
+			config:    "templates:\n  pullrequest:\n    disable: [Screenshots]\n",
+			templates: Builtin(),
+			wantErr:   `3:15: templates.pullrequest.disable[0]: unknown section "Screenshots" in Pull Request`,
+		},
+		{
+			name:      "custom template errors are positioned at templates",
+			config:    "templates:\n  pullrequest: {}\n",
+			templates: append(Builtin(), failing()),
+			wantErr:   "1:1: templates.failing: could not build",
+		},
+		{
+			name:      "duplicate paths",
+			config:    "templates:\n  readme:\n    name: Widget\n    features: Fast\n    quickstart: go get widget\n    path: SECURITY.md\n",
+			templates: append(Builtin(), securityPolicy()),
+			wantErr:   "templates.security.path: a document is already registered at SECURITY.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config.Parse() error = %v", err)
+			}
+
+			docs, err := Documents(cfg, tt.templates...)
+			if tt.wantErr != "" {
+				var invalid *config.ValidationError
+				if !errors.As(err, &invalid) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Documents() error = %v, should be a *config.ValidationError containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Documents() error = %v", err)
+			}
+
+			var paths []string
+			for _, entry := range docs.Entries() {
+				paths = append(paths, entry.Path)
+			}
+
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Documents() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestDocumentsWithoutConfig(t *testing.T) {
+	docs, err := Documents(nil, append(Builtin(), securityPolicy())...)
+	if err != nil {
+		t.Fatalf("Documents() error = %v", err)
+	}
+
+	entries := docs.Entries()
+	if len(entries) != 1 || entries[0].Path != "SECURITY.md" {
+		t.Errorf("Documents() entries = %v, want only SECURITY.md", entries)
+	}
+}
+
+func TestBuiltinDocuments(t *testing.T) {
+	cfg, err := config.Parse([]byte(FULL_CONFIG))
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+
+	docs, err := Documents(cfg, Builtin()...)
+	if err != nil {
+		t.Fatalf("Documents() error = %v", err)
+	}
+
+	tests := []struct {
+		path      string
+		contains  []string
+		excludes  []string
+		inOrder   []string
+		firstLine string
+	}{
+		{
+			path:     "README.md",
+			contains: []string{"Widgets for everyone.", "## Features\n\n- Fast\n- Small", "## Usage\n\nCall widget.New.", "MIT License -", "- [Usage](#usage)"},
+			inOrder:  []string{"## Table of contents", "## Quickstart", "## Usage", "## Contributing", "## License"},
+		},
+		{
+			path:     "docs/CONTRIBUTING.md",
+			contains: []string{"Signed-off-by", "git clone", "git rebase --signoff origin/develop"},
+			excludes: []string{"## Writing documentation", "Fork the repository"},
+		},
+		{
+			path:      ".github/PULL_REQUEST_TEMPLATE.md",
+			firstLine: "# Change request",
+			inOrder:   []string{"## How I tested", "## Security impact\n\nDoes this change how credentials are handled?"},
+		},
+		{
+			path:     ".github/ISSUE_TEMPLATE/bug_report.md",
+			contains: []string{"about: Report a widget bug", "assignees: octocat,hubot", "labels: bug", "name: Bug Report"},
+			excludes: []string{"Code Samples"},
+		},
+	}
+
+	entries := docs.Entries()
+	if len(entries) != len(tests) {
+		t.Fatalf("Documents() registered %d documents, want %d", len(entries), len(tests))
+	}
+
+	for idx, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			entry := entries[idx]
+			if entry.Path != tt.path {
+				t.Fatalf("Path = %q, want %q", entry.Path, tt.path)
+			}
+
+			content := render(t, entry.Document)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(content, want) {
+					t.Errorf("document should contain %q:\n%s", want, content)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(content, unwanted) {
+					t.Errorf("document should not contain %q:\n%s", unwanted, content)
+				}
+			}
+
+			last := -1
+			for _, want := range tt.inOrder {
+				idx := strings.Index(content, want)
+				if idx <= last {
+					t.Errorf("document should contain %q after the previous heading:\n%s", want, content)
+				}
+				last = idx
+			}
+
+			if tt.firstLine != "" && !strings.HasPrefix(content, tt.firstLine+"\n") {
+				t.Errorf("document should start with %q:\n%s", tt.firstLine, content)
+			}
+		})
+	}
+}
+
+func TestBuiltinDocumentsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "readme without a name",
+			config: "templates:\n  readme:\n    features: x\n    quickstart: y\n",
+			want:   `2:3: templates.readme: missing required field "name"`,
+		},
+		{
+			name:   "unknown disabled section",
+			config: "templates:\n  pullrequest:\n    disable:\n      - Description\n      - Screenshots\n",
+			want:   `5:9: templates.pullrequest.disable[1]: unknown section "Screenshots" in Pull Request, expected one of Related issue, How I tested`,
+		},
+		{
+			name:   "invalid project url",
+			config: "templates:\n  contributing:\n    project_url: not a url\n",
+			want:   "2:3: templates.contributing: could not extract project name from projectUrl",
+		},
+		{
+			name:   "invalid license id",
+			config: "templates:\n  contributing:\n    project_url: https://github.com/acme/widget\n    license_id: not spdx\n",
+			want:   `2:3: templates.contributing: invalid SPDX license identifier: "not spdx"`,
+		},
+		{
+			name:   "profile without tests",
+			config: "templates:\n  contributing:\n    project_url: https://github.com/acme/widget\n    profile:\n      name: Zig\n      test: []\n",
+			want:   "4:5: templates.contributing.profile: profile Zig must include at least one test command",
+		},
+		{
+			name:   "negative table of contents depth",
+			config: "templates:\n  readme:\n    name: widget\n    features: x\n    quickstart: y\n    table_of_contents: -1\n",
+			want:   "6:5: templates.readme.table_of_contents: table of contents depth must be at least 1, got -1",
+		},
+		{
+			name:   "duplicate paths",
+			config: "templates:\n  pullrequest: {}\n  bugreport:\n    path: .github/PULL_REQUEST_TEMPLATE.md\n",
+			want:   "4:5: templates.bugreport.path: a document is already registered at .github/PULL_REQUEST_TEMPLATE.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("config.Parse() error = %v", err)
+			}
+
+			_, err = Documents(cfg, Builtin()...)
+
+			var invalid *config.ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Documents() error = %v, want a *ValidationError", err)
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("Documents() error = %q, want prefix %q", err, tt.want)
+			}
+		})
+	}
+}
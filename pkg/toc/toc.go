@@ -0,0 +1,171 @@
+// Package toc generates tables of contents for doyoucompute documents.
+//
+// Anchors follow GitHub's heading slug rules, including the numeric suffixes
+// GitHub adds to repeated headings, so links work when the rendered markdown is
+// viewed on GitHub.
+//
+// Basic usage:
+//
+//	doc, err := contributing.New(projectUrl, "")
+//	if err != nil {
+//		// handle error
+//	}
+//	if err := toc.Insert(&doc, 2); err != nil {
+//		// handle error
+//	}
+package toc
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+// DEFAULT_TITLE is the heading of the generated table of contents section.
+const DEFAULT_TITLE = "Table of contents"
+
+// Entry is a heading listed in the table of contents.
+type Entry struct {
+	// Level is the nesting depth of the section, 1 for top-level sections
+	Level int
+	// Title is the heading text
+	Title string
+	// Anchor is the heading's slug, without the leading "#"
+	Anchor string
+}
+
+// Slug returns the anchor GitHub generates for a heading.
+// Letters and numbers are lowercased, spaces become hyphens,
+// and punctuation other than hyphens and underscores is dropped.
+func Slug(heading string) string {
+	var builder strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), unicode.Is(unicode.Mn, r), r == '-', r == '_':
+			builder.WriteRune(r)
+		case r == ' ':
+			builder.WriteRune('-')
+		}
+	}
+
+	return builder.String()
+}
+
+// Slugger generates unique anchors for the headings of a document in order,
+// suffixing repeated headings with "-1", "-2" and so on.
+type Slugger struct {
+	seen map[string]bool
+}
+
+// NewSlugger returns a Slugger with no headings seen.
+func NewSlugger() *Slugger {
+	return &Slugger{seen: map[string]bool{}}
+}
+
+// Slug returns the unique anchor for the next heading.
+func (s *Slugger) Slug(heading string) string {
+	base := Slug(heading)
+	slug := base
+
+	for idx := 1; s.seen[slug]; idx++ {
+		slug = fmt.Sprintf("%s-%d", base, idx)
+	}
+
+	s.seen[slug] = true
+
+	return slug
+}
+
+// Entries returns the sections of the document down to depth, in document order.
+// Anchors account for every heading in the document, including the title and
+// sections deeper than depth, so they match the rendered markdown.
+func Entries(doc doyoucompute.Document, depth int) []Entry {
+	return entries(doc, depth, nil)
+}
+
+// entries walks the document, skipping the node at skip when it is non-nil.
+func entries(doc doyoucompute.Document, depth int, skip doyoucompute.Node) []Entry {
+	slugger := NewSlugger()
+	slugger.Slug(doc.Name)
+
+	var found []Entry
+
+	var walk func(nodes []doyoucompute.Node, level int)
+	walk = func(nodes []doyoucompute.Node, level int) {
+		for _, node := range nodes {
+			if node.Type() != doyoucompute.SectionType {
+				continue
+			}
+
+			section, ok := node.(doyoucompute.Structurer)
+			if !ok {
+				continue
+			}
+
+			anchor := slugger.Slug(section.Identifier())
+			if skip != nil && node == skip {
+				continue
+			}
+
+			if level <= depth {
+				found = append(found, Entry{Level: level, Title: section.Identifier(), Anchor: anchor})
+			}
+
+			walk(section.Children(), level+1)
+		}
+	}
+
+	walk(doc.Content, 1)
+
+	return found
+}
+
+// List returns a paragraph rendering the entries as a nested markdown list of links.
+func List(entries []Entry) *doyoucompute.Paragraph {
+	lines := make([]string, len(entries))
+
+	for idx, entry := range entries {
+		title := strings.NewReplacer("[", "\\[", "]", "\\]").Replace(entry.Title)
+		lines[idx] = fmt.Sprintf("%s- [%s](#%s)", strings.Repeat("  ", entry.Level-1), title, entry.Anchor)
+	}
+
+	return doyoucompute.NewParagraph().Text(strings.Join(lines, "\n"))
+}
+
+// Insert adds a table of contents section before the first section of the document,
+// after any introduction paragraphs. Sections are listed down to depth, where 1
+// lists only top-level sections. Documents without sections are left unchanged.
+func Insert(doc *doyoucompute.Document, depth int) error {
+	if depth < 1 {
+		return fmt.Errorf("table of contents depth must be at least 1, got %d", depth)
+	}
+
+	position := -1
+	for idx, node := range doc.Content {
+		if node.Type() == doyoucompute.SectionType {
+			position = idx
+			break
+		}
+	}
+
+	if position == -1 {
+		return nil
+	}
+
+	// The section is inserted first so its own heading is counted when slugging
+	contents := doyoucompute.NewSection(DEFAULT_TITLE)
+	placeholder := &contents
+
+	content := make([]doyoucompute.Node, 0, len(doc.Content)+1)
+	content = append(content, doc.Content[:position]...)
+	content = append(content, placeholder)
+	content = append(content, doc.Content[position:]...)
+	doc.Content = content
+
+	contents.AddIntro(List(entries(*doc, depth, placeholder)))
+
+	return nil
+}
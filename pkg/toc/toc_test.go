@@ -0,0 +1,159 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{heading: "Getting started", want: "getting-started"},
+		{heading: "Setting Up Your Development Environment", want: "setting-up-your-development-environment"},
+		{heading: "What's new?", want: "whats-new"},
+		{heading: "API v2.0 (beta)", want: "api-v20-beta"},
+		{heading: "snake_case and kebab-case", want: "snake_case-and-kebab-case"},
+		{heading: "Foo & Bar", want: "foo--bar"},
+		{heading: "`make test`", want: "make-test"},
+		{heading: "Café déjà vu", want: "café-déjà-vu"},
+		{heading: "  Padded  ", want: "padded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			if got := Slug(tt.heading); got != tt.want {
+				t.Errorf("Slug() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSluggerDuplicates(t *testing.T) {
+	slugger := NewSlugger()
+
+	got := []string{
+		slugger.Slug("Usage"),
+		slugger.Slug("Usage"),
+		slugger.Slug("Usage-1"),
+		slugger.Slug("Usage"),
+	}
+	want := []string{"usage", "usage-1", "usage-1-1", "usage-2"}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Slugger.Slug() = %v, want %v", got, want)
+	}
+}
+
+func testDocument(t *testing.T) doyoucompute.Document {
+	t.Helper()
+
+	doc, err := doyoucompute.DocumentFactory("Project", func(d *doyoucompute.Document) error {
+		d.WriteIntro().Text("Intro text.")
+
+		usage := d.CreateSection("Usage")
+		examples := usage.CreateSection("Examples")
+		examples.CreateSection("Project")
+		usage.CreateSection("Usage")
+
+		api := doyoucompute.NewSection("API [beta]")
+		d.AddSection(api)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DocumentFactory() error = %v", err)
+	}
+
+	return doc
+}
+
+func TestEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth int
+		want  []Entry
+	}{
+		{
+			name:  "top level only",
+			depth: 1,
+			want: []Entry{
+				{Level: 1, Title: "Usage", Anchor: "usage"},
+				{Level: 1, Title: "API [beta]", Anchor: "api-beta"},
+			},
+		},
+		{
+			name:  "all levels",
+			depth: 3,
+			want: []Entry{
+				{Level: 1, Title: "Usage", Anchor: "usage"},
+				{Level: 2, Title: "Examples", Anchor: "examples"},
+				{Level: 3, Title: "Project", Anchor: "project-1"},
+				{Level: 2, Title: "Usage", Anchor: "usage-1"},
+				{Level: 1, Title: "API [beta]", Anchor: "api-beta"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Entries(testDocument(t), tt.depth)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Entries() = %+v, want %+v", got, tt.want)
+			}
+
+			for idx := range got {
+				if got[idx] != tt.want[idx] {
+					t.Errorf("Entries()[%d] = %+v, want %+v", idx, got[idx], tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	doc := testDocument(t)
+
+	if err := Insert(&doc, 2); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	renderer := doyoucompute.NewMarkdownRenderer()
+	rendered, err := renderer.Render(&doc)
+	if err != nil {
+		t.Fatalf("renderer.Render() error = %v", err)
+	}
+
+	want := "# Project\n\nIntro text.\n\n## Table of contents\n\n- [Usage](#usage)\n  - [Examples](#examples)\n  - [Usage](#usage-1)\n- [API \\[beta\\]](#api-beta)\n\n## Usage"
+	if !strings.Contains(rendered, want) {
+		t.Errorf("renderer.Render() = %q, should contain %q", rendered, want)
+	}
+
+	if strings.Contains(rendered, "(#table-of-contents)") {
+		t.Error("renderer.Render() should not list the table of contents itself")
+	}
+}
+
+func TestInsertEdgeCases(t *testing.T) {
+	doc, err := doyoucompute.DocumentFactory("Empty", func(d *doyoucompute.Document) error {
+		d.WriteIntro().Text("No sections here.")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DocumentFactory() error = %v", err)
+	}
+
+	if err := Insert(&doc, 2); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if len(doc.Content) != 1 {
+		t.Errorf("Insert() should leave documents without sections unchanged, got %d nodes", len(doc.Content))
+	}
+
+	if err := Insert(&doc, 0); err == nil || !strings.Contains(err.Error(), "depth must be at least 1") {
+		t.Errorf("Insert() error = %v, want depth error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prompter asks questions on out and reads the answers, one per line, from in.
+// Once in runs out every question is answered with its default.
+type prompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+	eof     bool
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{scanner: bufio.NewScanner(in), out: out}
+}
+
+// line reads the next answer, or returns an empty answer when in has run out.
+func (p *prompter) line() (string, error) {
+	if p.eof || !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", fmt.Errorf("could not read answer: %w", err)
+		}
+
+		p.eof = true
+
+		return "", nil
+	}
+
+	return strings.TrimSpace(p.scanner.Text()), nil
+}
+
+// ask prompts for a single line, returning def when the answer is blank.
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.line()
+	if err != nil {
+		return "", err
+	}
+
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// require prompts until valid accepts the answer, printing the reason it gives for asking again.
+// Fails when in runs out before a valid answer is given.
+func (p *prompter) require(question, def string, valid func(string) error) (string, error) {
+	for {
+		answer, err := p.ask(question, def)
+		if err != nil {
+			return "", err
+		}
+
+		reason := valid(answer)
+		if reason == nil {
+			return answer, nil
+		}
+
+		if p.eof {
+			return "", fmt.Errorf("no valid answer to %q: %w", question, reason)
+		}
+
+		fmt.Fprintln(p.out, reason)
+	}
+}
+
+// list prompts for one item per line until a blank line.
+func (p *prompter) list(question string) ([]string, error) {
+	fmt.Fprintf(p.out, "%s, one per line, finish with a blank line:\n", question)
+
+	var items []string
+
+	for {
+		fmt.Fprint(p.out, "> ")
+
+		item, err := p.line()
+		if err != nil {
+			return nil, err
+		}
+
+		if item == "" {
+			return items, nil
+		}
+
+		items = append(items, item)
+	}
+}
+
+// confirm prompts for a yes or no answer, returning def when the answer is blank.
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s (%s): ", question, hint)
+
+		answer, err := p.line()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+
+		fmt.Fprintln(p.out, "Please answer yes or no.")
+	}
+}
+
+// choose prompts for one of options, returning def when the answer is blank.
+func (p *prompter) choose(question string, options []string, def string) (string, error) {
+	return p.require(fmt.Sprintf("%s (%s)", question, strings.Join(options, ", ")), def, func(answer string) error {
+		for _, option := range options {
+			if answer == option {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%q is not one of %s", answer, strings.Join(options, ", "))
+	})
+}
@@ -0,0 +1,258 @@
+// Package wizard walks new users through the inputs the templates require.
+//
+// readme.New needs a name, features and a quickstart, and contributing.New needs
+// a project url. The wizard asks for each in turn, offering defaults detected from
+// the repository, previews the rendered documents and returns the answers as a
+// config.Config that can be saved and regenerated later without asking again.
+//
+// Basic usage:
+//
+//	info, err := metadata.Detect(os.DirFS("."))
+//	if err != nil {
+//		// handle error
+//	}
+//	cfg, err := wizard.Run(os.Stdin, os.Stdout, info)
+//	if err != nil {
+//		// handle error
+//	}
+//	content, err := cfg.Encode()
+//	if err != nil {
+//		// handle error
+//	}
+//	err = os.WriteFile(config.DEFAULT_PATH, content, 0o644)
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/MoonMoon1919/doyoucompute"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/templates"
+)
+
+// ErrCancelled is returned when the settings are not accepted after the preview.
+var ErrCancelled = errors.New("wizard cancelled")
+
+// Run asks for the inputs of each template on out, reading answers from in, and
+// returns the resulting config once its preview has been accepted.
+// Blank answers take the default shown in brackets, and when in runs out every
+// remaining question takes its default.
+//
+// Example:
+//
+//	cfg, err := wizard.Run(strings.NewReader("widget\n"), io.Discard, info)
+func Run(in io.Reader, out io.Writer, info metadata.ProjectInfo) (*config.Config, error) {
+	p := newPrompter(in, out)
+	cfg := &config.Config{}
+
+	fmt.Fprintln(out, "README")
+
+	readme, err := askReadme(p, info)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Templates.Readme = readme
+
+	fmt.Fprintln(out, "\nContributing guide")
+
+	contributing, err := askContributing(p, info)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Templates.Contributing = contributing
+
+	fmt.Fprintln(out, "\nIssue and pull request templates")
+
+	if ok, err := p.confirm("Add a pull request template?", true); err != nil {
+		return nil, err
+	} else if ok {
+		cfg.Templates.PullRequest = &config.PullRequest{}
+	}
+
+	if ok, err := p.confirm("Add a bug report template?", true); err != nil {
+		return nil, err
+	} else if ok {
+		cfg.Templates.BugReport = &config.BugReport{Labels: []config.Label{"bug"}}
+	}
+
+	if err := Preview(out, cfg); err != nil {
+		return nil, err
+	}
+
+	ok, err := p.confirm("\nKeep these settings?", true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrCancelled
+	}
+
+	return cfg, nil
+}
+
+// Preview renders every document in the config to out, each headed by its path.
+func Preview(out io.Writer, cfg *config.Config) error {
+	docs, err := templates.Documents(cfg, templates.Builtin()...)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range docs.Entries() {
+		content, err := doyoucompute.NewMarkdownRenderer().Render(&entry.Document)
+		if err != nil {
+			return fmt.Errorf("could not render %s: %w", entry.Path, err)
+		}
+
+		fmt.Fprintf(out, "\n==> %s <==\n\n%s", entry.Path, content)
+	}
+
+	return nil
+}
+
+func askReadme(p *prompter, info metadata.ProjectInfo) (*config.Readme, error) {
+	readme := &config.Readme{}
+
+	name, err := p.require("Project name", defaultName(info), notEmpty("the project name"))
+	if err != nil {
+		return nil, err
+	}
+	readme.Name = name
+
+	readme.Intro, err = p.ask("One sentence describing the project (optional)", "")
+	if err != nil {
+		return nil, err
+	}
+
+	for len(readme.Features) == 0 {
+		features, err := p.list("Main features")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(features) > 0 {
+			readme.Features = "- " + strings.Join(features, "\n- ")
+			break
+		}
+
+		if p.eof {
+			return nil, fmt.Errorf("no features given: %w", io.ErrUnexpectedEOF)
+		}
+
+		fmt.Fprintln(p.out, "At least one feature is required.")
+	}
+
+	install, err := p.require("Command to install or run the project", info.InstallCommand(), notEmpty("a command"))
+	if err != nil {
+		return nil, err
+	}
+	readme.QuickStart = fmt.Sprintf("```bash\n%s\n```", install)
+
+	var licenseName string
+	if info.License.Known() {
+		licenseName = info.License.Name
+	}
+
+	licenseName, err = p.ask("License name (optional)", licenseName)
+	if err != nil {
+		return nil, err
+	}
+
+	if licenseName != "" {
+		readme.License = &config.License{Name: licenseName}
+
+		if info.License.Path != "" {
+			readme.License.Path = info.License.Path
+		}
+	}
+
+	return readme, nil
+}
+
+func askContributing(p *prompter, info metadata.ProjectInfo) (*config.Contributing, error) {
+	ok, err := p.confirm("Add a contributing guide?", true)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var projectUrl string
+	if info.HasRepository() {
+		projectUrl = info.Repository.WebURL()
+	}
+
+	var repository repourl.URL
+
+	projectUrl, err = p.require("Repository url", projectUrl, func(answer string) error {
+		if answer == "" {
+			return errors.New("the repository url is required")
+		}
+
+		parsed, err := repourl.Parse(answer)
+		if err != nil {
+			return fmt.Errorf("invalid repository url: %w", err)
+		}
+
+		repository = parsed
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contributing := &config.Contributing{ProjectUrl: projectUrl}
+
+	// main is the default, so it is left out of the config
+	if info.DefaultBranch != "" && info.DefaultBranch != metadata.DEFAULT_BRANCH {
+		contributing.DefaultBranch = info.DefaultBranch
+	}
+
+	// The issue tracker only needs asking for when it cannot be derived from the repository
+	if _, err := repository.IssuesURL(); err != nil {
+		contributing.IssueTrackerUrl, err = p.require("Issue tracker url", "", notEmpty("the issue tracker url"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	presets := config.Preset("").Values()
+
+	preset, err := p.choose("Contribution workflow", presets, presets[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// The open source preset is the default, so it is left out of the config
+	if preset != presets[0] {
+		contributing.Preset = config.Preset(preset)
+	}
+
+	return contributing, nil
+}
+
+// defaultName returns the repository name, or the last element of the module path.
+func defaultName(info metadata.ProjectInfo) string {
+	switch {
+	case info.HasRepository():
+		return info.Repository.Name
+	case info.ModulePath != "":
+		return path.Base(info.ModulePath)
+	}
+
+	return ""
+}
+
+func notEmpty(what string) func(string) error {
+	return func(answer string) error {
+		if answer == "" {
+			return fmt.Errorf("%s is required", what)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,186 @@
+package wizard
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/config"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/metadata"
+	"github.com/MoonMoon1919/doyoucompute-templates/pkg/repourl"
+)
+
+func detected(t *testing.T) metadata.ProjectInfo {
+	t.Helper()
+
+	repository, err := repourl.Parse("https://github.com/acme/widget")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return metadata.ProjectInfo{
+		ModulePath: "github.com/acme/widget",
+		Repository: repository,
+		License:    metadata.License{SPDX: "MIT", Name: "MIT", Path: "./LICENSE"},
+	}
+}
+
+// answers joins the answers to each prompt into the input the wizard reads.
+func answers(lines ...string) io.Reader {
+	return strings.NewReader(strings.Join(lines, "\n") + "\n")
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    func(t *testing.T) metadata.ProjectInfo
+		input   io.Reader
+		want    string
+		wantOut []string
+		wantErr string
+	}{
+		{
+			name: "defaults from detected metadata",
+			info: detected,
+			input: answers(
+				"",                  // project name
+				"",                  // description
+				"Fast", "Small", "", // features
+				"",  // install command
+				"",  // license
+				"",  // contributing guide
+				"",  // repository url
+				"",  // workflow
+				"",  // pull request template
+				"n", // bug report template
+				"",  // keep
+			),
+			want: `templates:
+  readme:
+    name: widget
+    features: |-
+      - Fast
+      - Small
+    quickstart: |-
+      ` + "```bash" + `
+      go get github.com/acme/widget
+      ` + "```" + `
+    license:
+      name: MIT
+      path: ./LICENSE
+  contributing:
+    project_url: https://github.com/acme/widget
+  pullrequest: {}
+`,
+			wantOut: []string{
+				"Project name [widget]: ",
+				"Command to install or run the project [go get github.com/acme/widget]: ",
+				"==> README.md <==\n\n# widget\n",
+				"==> CONTRIBUTING.md <==",
+				"==> .github/PULL_REQUEST_TEMPLATE.md <==",
+			},
+		},
+		{
+			name: "nothing detected",
+			info: func(*testing.T) metadata.ProjectInfo { return metadata.ProjectInfo{} },
+			input: answers(
+				"",                // project name, required
+				"gadget",          // project name
+				"Gadgets for all", // description
+				"",                // no features
+				"Portable", "",    // features
+				"",                                    // install command, required
+				"make install",                        // install command
+				"Apache 2.0",                          // license
+				"y",                                   // contributing guide
+				"not a url",                           // repository url
+				"https://git.example.com/acme/gadget", // repository url on an unknown forge
+				"https://tracker.example.com",         // issue tracker url
+				"closed",                              // workflow
+				"internal",                            // workflow
+				"n",                                   // pull request template
+				"y",                                   // bug report template
+				"yes",                                 // keep
+			),
+			want: `templates:
+  readme:
+    name: gadget
+    intro: Gadgets for all
+    features: '- Portable'
+    quickstart: |-
+      ` + "```bash" + `
+      make install
+      ` + "```" + `
+    license:
+      name: Apache 2.0
+  contributing:
+    project_url: https://git.example.com/acme/gadget
+    issue_tracker_url: https://tracker.example.com
+    preset: internal
+  bugreport:
+    labels:
+      - bug
+`,
+			wantOut: []string{
+				"the project name is required",
+				"At least one feature is required.",
+				"a command is required",
+				"invalid repository url",
+				`"closed" is not one of open-source, internal`,
+				"==> .github/ISSUE_TEMPLATE/bug_report.md <==",
+			},
+		},
+		{
+			name:    "input runs out before a required answer",
+			info:    func(*testing.T) metadata.ProjectInfo { return metadata.ProjectInfo{} },
+			input:   strings.NewReader(""),
+			wantErr: `no valid answer to "Project name": the project name is required`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			cfg, err := Run(tt.input, &out, tt.info(t))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v\n%s", err, out.String())
+			}
+
+			content, err := cfg.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			got := strings.SplitN(string(content), "\n", 2)[1]
+			if got != tt.want {
+				t.Errorf("Run() config =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Run() output should contain %q:\n%s", want, out.String())
+				}
+			}
+
+			// The saved config must generate the same documents without the wizard
+			if _, err := config.Parse(content); err != nil {
+				t.Errorf("Parse() of the saved config error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	_, err := Run(answers("", "", "Fast", "", "", "", "n", "n", "n", "n"), io.Discard, detected(t))
+	if !errors.Is(err, ErrCancelled) {
+		t.Errorf("Run() error = %v, want ErrCancelled", err)
+	}
+}